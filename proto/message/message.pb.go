@@ -42,6 +42,12 @@ const (
 	MessageType_CHAT_LOG MessageType = 5
 	//All available RLEs that the server knows about
 	MessageType_RLE_OPTIONS MessageType = 6
+	//The world has become periodic
+	MessageType_STABILIZED MessageType = 7
+	//How many of each kind of object are in the world
+	MessageType_CENSUS MessageType = 8
+	//Statistics of the world's recent generations
+	MessageType_STATS MessageType = 9
 )
 
 // Enum value maps for MessageType.
@@ -54,6 +60,9 @@ var (
 		4: "RESPONSE",
 		5: "CHAT_LOG",
 		6: "RLE_OPTIONS",
+		7: "STABILIZED",
+		8: "CENSUS",
+		9: "STATS",
 	}
 	MessageType_value = map[string]int32{
 		"REGISTER":    0,
@@ -63,6 +72,9 @@ var (
 		"RESPONSE":    4,
 		"CHAT_LOG":    5,
 		"RLE_OPTIONS": 6,
+		"STABILIZED":  7,
+		"CENSUS":      8,
+		"STATS":       9,
 	}
 )
 
@@ -101,23 +113,74 @@ const (
 	CommandType_TOGGLE_PAUSE CommandType = 2
 	CommandType_POST_CHAT    CommandType = 3
 	CommandType_CLEAR_BOARD  CommandType = 4
+	//moves a paused world back or forward through its history by one generation, or to the given tick
+	CommandType_STEP_BACK    CommandType = 5
+	CommandType_STEP_FORWARD CommandType = 6
+	CommandType_JUMP_TO_TICK CommandType = 7
+	//fills the region with its top-left corner at (x, y) with a random soup, using text as the seed
+	CommandType_RANDOMIZE_REGION CommandType = 8
+	//sets the cell at (x, y) to the given state of the rule, such as the conductor of Wireworld. State 0 erases it
+	CommandType_PAINT_STATE CommandType = 9
+	//runs a world with a reversible block rule backward (or forward again) while it isn't paused
+	CommandType_TOGGLE_REVERSE CommandType = 10
+	//resizes the world to width by height, keeping the anchor given in text (center, top-left, ...) in place. Only
+	//accepted with the server's admin key, and followed by a full world message with the new dimensions
+	CommandType_RESIZE CommandType = 11
+	//copy or cut the width by height region with its top-left corner at (x, y) into the player's clipboard
+	CommandType_COPY_REGION CommandType = 12
+	CommandType_CUT_REGION  CommandType = 13
+	//pastes the player's clipboard with its top-left corner at (x, y), merged with the world in the mode given in text
+	//(overwrite, or, xor or and-not; overwrite by default)
+	CommandType_PASTE CommandType = 14
+	//moves the region to (to_x, to_y), merged with the world in the mode given in text
+	CommandType_MOVE_REGION  CommandType = 15
+	CommandType_CLEAR_REGION CommandType = 16
+	//replaces a paused world with a fork of it from the tick in its history, dropping the generations after the tick for
+	//good. Followed by a full world message, as the history starts over from the tick
+	CommandType_FORK CommandType = 17
 )
 
 // Enum value maps for CommandType.
 var (
 	CommandType_name = map[int32]string{
-		0: "MARK_CELL",
-		1: "PLACE_RLE",
-		2: "TOGGLE_PAUSE",
-		3: "POST_CHAT",
-		4: "CLEAR_BOARD",
+		0:  "MARK_CELL",
+		1:  "PLACE_RLE",
+		2:  "TOGGLE_PAUSE",
+		3:  "POST_CHAT",
+		4:  "CLEAR_BOARD",
+		5:  "STEP_BACK",
+		6:  "STEP_FORWARD",
+		7:  "JUMP_TO_TICK",
+		8:  "RANDOMIZE_REGION",
+		9:  "PAINT_STATE",
+		10: "TOGGLE_REVERSE",
+		11: "RESIZE",
+		12: "COPY_REGION",
+		13: "CUT_REGION",
+		14: "PASTE",
+		15: "MOVE_REGION",
+		16: "CLEAR_REGION",
+		17: "FORK",
 	}
 	CommandType_value = map[string]int32{
-		"MARK_CELL":    0,
-		"PLACE_RLE":    1,
-		"TOGGLE_PAUSE": 2,
-		"POST_CHAT":    3,
-		"CLEAR_BOARD":  4,
+		"MARK_CELL":        0,
+		"PLACE_RLE":        1,
+		"TOGGLE_PAUSE":     2,
+		"POST_CHAT":        3,
+		"CLEAR_BOARD":      4,
+		"STEP_BACK":        5,
+		"STEP_FORWARD":     6,
+		"JUMP_TO_TICK":     7,
+		"RANDOMIZE_REGION": 8,
+		"PAINT_STATE":      9,
+		"TOGGLE_REVERSE":   10,
+		"RESIZE":           11,
+		"COPY_REGION":      12,
+		"CUT_REGION":       13,
+		"PASTE":            14,
+		"MOVE_REGION":      15,
+		"CLEAR_REGION":     16,
+		"FORK":             17,
 	}
 )
 
@@ -257,6 +320,8 @@ type Player struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	//24bit color
 	Color uint32 `protobuf:"fixed32,2,opt,name=color,proto3" json:"color,omitempty"`
+	//the ID that owns cells in ownership mode
+	Id uint32 `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (x *Player) Reset() {
@@ -305,6 +370,13 @@ func (x *Player) GetColor() uint32 {
 	return 0
 }
 
+func (x *Player) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type WorldData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -315,6 +387,36 @@ type WorldData struct {
 	Width  uint32   `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
 	Height uint32   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
 	Paused bool     `protobuf:"varint,5,opt,name=paused,proto3" json:"paused,omitempty"`
+	//the rulestring the world is running, in B/S notation
+	Rule string `protobuf:"bytes,6,opt,name=rule,proto3" json:"rule,omitempty"`
+	//the number of cell states of the rule; 2 for Life-like rules. For Generations rules (more than 2 states), alive
+	//cells always have a lower byte of 0xFF, and refractory cells are sent as alive with a lower byte of
+	//0xFF - 2*(state-1)
+	States uint32 `protobuf:"varint,7,opt,name=states,proto3" json:"states,omitempty"`
+	//the coordinates of the top-left cell of the data. Always 0 for fixed-size worlds, but unbounded worlds send the
+	//bounding box of their cells (at most 1024 cells across, around where most of them are), with width and height
+	//giving its size
+	OriginY int64 `protobuf:"zigzag64,8,opt,name=origin_y,json=originY,proto3" json:"origin_y,omitempty"`
+	OriginX int64 `protobuf:"zigzag64,9,opt,name=origin_x,json=originX,proto3" json:"origin_x,omitempty"`
+	//the oldest and newest ticks the world can jump to, when it keeps a history
+	HistoryOldest uint64 `protobuf:"varint,10,opt,name=history_oldest,json=historyOldest,proto3" json:"history_oldest,omitempty"`
+	HistoryNewest uint64 `protobuf:"varint,11,opt,name=history_newest,json=historyNewest,proto3" json:"history_newest,omitempty"`
+	//set when the top 3 bytes of the cells hold the ID of the player that owns them (0 for nobody) instead of a color
+	Ownership bool `protobuf:"varint,12,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	//the name of the color rule of the world, such as "rgb" or "quadlife"
+	ColorRule string `protobuf:"bytes,13,opt,name=color_rule,json=colorRule,proto3" json:"color_rule,omitempty"`
+	//the colors of the states of named multi-state rules like Wireworld, starting from state 0, whose cells are dead.
+	//Empty for other rules
+	StateColors []uint32 `protobuf:"fixed32,14,rep,packed,name=state_colors,json=stateColors,proto3" json:"state_colors,omitempty"`
+	//set when the world runs a reversible block rule like Critters, which TOGGLE_REVERSE can run backward
+	Reversible bool `protobuf:"varint,15,opt,name=reversible,proto3" json:"reversible,omitempty"`
+	//the rectangles of the world that data holds the cells of, when partial is set. Each rectangle is 4 numbers: the y
+	//and x of its top-left corner, its height and its width. Their cells are flattened one rectangle after the other,
+	//row by row
+	ChangedRegions []uint32 `protobuf:"varint,16,rep,packed,name=changed_regions,json=changedRegions,proto3" json:"changed_regions,omitempty"`
+	//set when data only holds the cells of changed_regions (which may be none), and the rest of the world is unchanged
+	//since the last frame. Clients without a full frame yet have to wait for one
+	Partial bool `protobuf:"varint,17,opt,name=partial,proto3" json:"partial,omitempty"`
 }
 
 func (x *WorldData) Reset() {
@@ -384,6 +486,90 @@ func (x *WorldData) GetPaused() bool {
 	return false
 }
 
+func (x *WorldData) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *WorldData) GetStates() uint32 {
+	if x != nil {
+		return x.States
+	}
+	return 0
+}
+
+func (x *WorldData) GetOriginY() int64 {
+	if x != nil {
+		return x.OriginY
+	}
+	return 0
+}
+
+func (x *WorldData) GetOriginX() int64 {
+	if x != nil {
+		return x.OriginX
+	}
+	return 0
+}
+
+func (x *WorldData) GetHistoryOldest() uint64 {
+	if x != nil {
+		return x.HistoryOldest
+	}
+	return 0
+}
+
+func (x *WorldData) GetHistoryNewest() uint64 {
+	if x != nil {
+		return x.HistoryNewest
+	}
+	return 0
+}
+
+func (x *WorldData) GetOwnership() bool {
+	if x != nil {
+		return x.Ownership
+	}
+	return false
+}
+
+func (x *WorldData) GetColorRule() string {
+	if x != nil {
+		return x.ColorRule
+	}
+	return ""
+}
+
+func (x *WorldData) GetStateColors() []uint32 {
+	if x != nil {
+		return x.StateColors
+	}
+	return nil
+}
+
+func (x *WorldData) GetReversible() bool {
+	if x != nil {
+		return x.Reversible
+	}
+	return false
+}
+
+func (x *WorldData) GetChangedRegions() []uint32 {
+	if x != nil {
+		return x.ChangedRegions
+	}
+	return nil
+}
+
+func (x *WorldData) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
 type ServerData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -440,6 +626,24 @@ type Command struct {
 	X    uint32      `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"`
 	Y    uint32      `protobuf:"varint,3,opt,name=y,proto3" json:"y,omitempty"`
 	Text string      `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Tick uint64      `protobuf:"varint,5,opt,name=tick,proto3" json:"tick,omitempty"`
+	//the size, density (between 0 and 1) and symmetry (C1, C2, C4, D2, D4 or D8) of a soup
+	Width    uint32  `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height   uint32  `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	Density  float32 `protobuf:"fixed32,8,opt,name=density,proto3" json:"density,omitempty"`
+	Symmetry string  `protobuf:"bytes,9,opt,name=symmetry,proto3" json:"symmetry,omitempty"`
+	//the state painted by PAINT_STATE
+	State uint32 `protobuf:"varint,10,opt,name=state,proto3" json:"state,omitempty"`
+	//the key admin commands like RESIZE are checked against
+	AdminKey string `protobuf:"bytes,11,opt,name=admin_key,json=adminKey,proto3" json:"admin_key,omitempty"`
+	//where MOVE_REGION moves the region to
+	ToX uint32 `protobuf:"varint,12,opt,name=to_x,json=toX,proto3" json:"to_x,omitempty"`
+	ToY uint32 `protobuf:"varint,13,opt,name=to_y,json=toY,proto3" json:"to_y,omitempty"`
+	//the origin_y and origin_x of the world data the client clicked on, which x and y are relative to. Unbounded worlds
+	//move their frame as they evolve, so the cell at (x, y) of a frame is at (origin_x + x, origin_y + y) in the world.
+	//Always 0 for fixed-size worlds
+	OriginY int64 `protobuf:"zigzag64,14,opt,name=origin_y,json=originY,proto3" json:"origin_y,omitempty"`
+	OriginX int64 `protobuf:"zigzag64,15,opt,name=origin_x,json=originX,proto3" json:"origin_x,omitempty"`
 }
 
 func (x *Command) Reset() {
@@ -502,6 +706,83 @@ func (x *Command) GetText() string {
 	return ""
 }
 
+func (x *Command) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *Command) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Command) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Command) GetDensity() float32 {
+	if x != nil {
+		return x.Density
+	}
+	return 0
+}
+
+func (x *Command) GetSymmetry() string {
+	if x != nil {
+		return x.Symmetry
+	}
+	return ""
+}
+
+func (x *Command) GetState() uint32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *Command) GetAdminKey() string {
+	if x != nil {
+		return x.AdminKey
+	}
+	return ""
+}
+
+func (x *Command) GetToX() uint32 {
+	if x != nil {
+		return x.ToX
+	}
+	return 0
+}
+
+func (x *Command) GetToY() uint32 {
+	if x != nil {
+		return x.ToY
+	}
+	return 0
+}
+
+func (x *Command) GetOriginY() int64 {
+	if x != nil {
+		return x.OriginY
+	}
+	return 0
+}
+
+func (x *Command) GetOriginX() int64 {
+	if x != nil {
+		return x.OriginX
+	}
+	return 0
+}
+
 type Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -612,6 +893,435 @@ func (x *Chat) GetText() string {
 	return ""
 }
 
+type Stabilization struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//every generation since tick repeats period generations later; a period of 1 means the world stopped changing
+	Tick   uint64 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Period uint64 `protobuf:"varint,2,opt,name=period,proto3" json:"period,omitempty"`
+	//the tick when the world was found to be periodic, and how many cells were alive then
+	DetectedAt uint64 `protobuf:"varint,3,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	Population uint64 `protobuf:"varint,4,opt,name=population,proto3" json:"population,omitempty"`
+	//what the server did about it: announce, pause or reset
+	Action string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+}
+
+func (x *Stabilization) Reset() {
+	*x = Stabilization{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stabilization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stabilization) ProtoMessage() {}
+
+func (x *Stabilization) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stabilization.ProtoReflect.Descriptor instead.
+func (*Stabilization) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Stabilization) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *Stabilization) GetPeriod() uint64 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *Stabilization) GetDetectedAt() uint64 {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return 0
+}
+
+func (x *Stabilization) GetPopulation() uint64 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *Stabilization) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type Census struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tick uint64 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	//the most common objects first
+	Entries []*CensusEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *Census) Reset() {
+	*x = Census{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Census) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Census) ProtoMessage() {}
+
+func (x *Census) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Census.ProtoReflect.Descriptor instead.
+func (*Census) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Census) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *Census) GetEntries() []*CensusEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type CensusEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//the apgcode of the object, such as xs4_33 for a block or xq4_153 for a glider, or empty for unclassified objects
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	//the common name of the object, if it has one
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	//still life, oscillator, spaceship or unclassified
+	Type   string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Period uint32 `protobuf:"varint,4,opt,name=period,proto3" json:"period,omitempty"`
+	//how far a spaceship moves every period
+	Dy    int64  `protobuf:"zigzag64,5,opt,name=dy,proto3" json:"dy,omitempty"`
+	Dx    int64  `protobuf:"zigzag64,6,opt,name=dx,proto3" json:"dx,omitempty"`
+	Count uint32 `protobuf:"varint,7,opt,name=count,proto3" json:"count,omitempty"`
+	//such as c/4 diagonal for a glider
+	Speed string `protobuf:"bytes,8,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *CensusEntry) Reset() {
+	*x = CensusEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CensusEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CensusEntry) ProtoMessage() {}
+
+func (x *CensusEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CensusEntry.ProtoReflect.Descriptor instead.
+func (*CensusEntry) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CensusEntry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CensusEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CensusEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CensusEntry) GetPeriod() uint32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *CensusEntry) GetDy() int64 {
+	if x != nil {
+		return x.Dy
+	}
+	return 0
+}
+
+func (x *CensusEntry) GetDx() int64 {
+	if x != nil {
+		return x.Dx
+	}
+	return 0
+}
+
+func (x *CensusEntry) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CensusEntry) GetSpeed() string {
+	if x != nil {
+		return x.Speed
+	}
+	return ""
+}
+
+type Stats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//oldest first; a single generation as the world ticks, or all of them kept when a client joins
+	Ticks []*TickStats `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty"`
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Stats) GetTicks() []*TickStats {
+	if x != nil {
+		return x.Ticks
+	}
+	return nil
+}
+
+type TickStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tick       uint64 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Population uint64 `protobuf:"varint,2,opt,name=population,proto3" json:"population,omitempty"`
+	Births     uint64 `protobuf:"varint,3,opt,name=births,proto3" json:"births,omitempty"`
+	Deaths     uint64 `protobuf:"varint,4,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	//the live cells by the player color closest to their own
+	Colors []*ColorCount `protobuf:"bytes,5,rep,name=colors,proto3" json:"colors,omitempty"`
+}
+
+func (x *TickStats) Reset() {
+	*x = TickStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TickStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TickStats) ProtoMessage() {}
+
+func (x *TickStats) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TickStats.ProtoReflect.Descriptor instead.
+func (*TickStats) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TickStats) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *TickStats) GetPopulation() uint64 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *TickStats) GetBirths() uint64 {
+	if x != nil {
+		return x.Births
+	}
+	return 0
+}
+
+func (x *TickStats) GetDeaths() uint64 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *TickStats) GetColors() []*ColorCount {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+type ColorCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color uint32 `protobuf:"fixed32,1,opt,name=color,proto3" json:"color,omitempty"`
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ColorCount) Reset() {
+	*x = ColorCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColorCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColorCount) ProtoMessage() {}
+
+func (x *ColorCount) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColorCount.ProtoReflect.Descriptor instead.
+func (*ColorCount) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ColorCount) GetColor() uint32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *ColorCount) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 type RLEs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -623,7 +1333,7 @@ type RLEs struct {
 func (x *RLEs) Reset() {
 	*x = RLEs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[7]
+		mi := &file_message_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -636,7 +1346,7 @@ func (x *RLEs) String() string {
 func (*RLEs) ProtoMessage() {}
 
 func (x *RLEs) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[7]
+	mi := &file_message_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,7 +1359,7 @@ func (x *RLEs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RLEs.ProtoReflect.Descriptor instead.
 func (*RLEs) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{7}
+	return file_message_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RLEs) GetRles() []*RLE {
@@ -673,7 +1383,7 @@ type RLE struct {
 func (x *RLE) Reset() {
 	*x = RLE{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[8]
+		mi := &file_message_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -686,7 +1396,7 @@ func (x *RLE) String() string {
 func (*RLE) ProtoMessage() {}
 
 func (x *RLE) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[8]
+	mi := &file_message_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,7 +1409,7 @@ func (x *RLE) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RLE.ProtoReflect.Descriptor instead.
 func (*RLE) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{8}
+	return file_message_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RLE) GetName() string {
@@ -739,63 +1449,161 @@ var file_message_proto_rawDesc = []byte{
 	0x0e, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4d, 0x65, 0x73, 0x73,
 	0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
 	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x32, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x42, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65,
 	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x07, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x79, 0x0a, 0x09, 0x57,
-	0x6f, 0x72, 0x6c, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x07, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x69, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x63, 0x6b,
-	0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22, 0x37, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x44, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
-	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22,
-	0x63, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x79,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x65, 0x78, 0x74, 0x22, 0x49, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x29, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15,
-	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
-	0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22,
-	0x43, 0x0a, 0x04, 0x43, 0x68, 0x61, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x65, 0x78, 0x74, 0x22, 0x28, 0x0a, 0x04, 0x52, 0x4c, 0x45, 0x73, 0x12, 0x20, 0x0a, 0x04,
-	0x72, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x4c, 0x45, 0x52, 0x04, 0x72, 0x6c, 0x65, 0x73, 0x22, 0x5b,
-	0x0a, 0x03, 0x52, 0x4c, 0x45, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64,
-	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12,
-	0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x2a, 0x76, 0x0a, 0x0b, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45,
-	0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x45, 0x52, 0x56,
-	0x45, 0x52, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x4f, 0x52,
-	0x4c, 0x44, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4d,
-	0x4d, 0x41, 0x4e, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e,
-	0x53, 0x45, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x4c, 0x4f, 0x47,
-	0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4c, 0x45, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e,
-	0x53, 0x10, 0x06, 0x2a, 0x5d, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x43, 0x45, 0x4c, 0x4c, 0x10,
-	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x4c, 0x41, 0x43, 0x45, 0x5f, 0x52, 0x4c, 0x45, 0x10, 0x01,
-	0x12, 0x10, 0x0a, 0x0c, 0x54, 0x4f, 0x47, 0x47, 0x4c, 0x45, 0x5f, 0x50, 0x41, 0x55, 0x53, 0x45,
-	0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x4f, 0x53, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x54, 0x10,
-	0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x42, 0x4f, 0x41, 0x52, 0x44,
-	0x10, 0x04, 0x2a, 0x38, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f,
-	0x64, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x49, 0x43, 0x5f, 0x53, 0x55,
-	0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x47, 0x45, 0x4e, 0x45, 0x52,
-	0x49, 0x43, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x01, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x20, 0x01, 0x28, 0x07, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x22, 0xec, 0x03, 0x0a, 0x09,
+	0x57, 0x6f, 0x72, 0x6c, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x07, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x63,
+	0x6b, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x79, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x12, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x59, 0x12, 0x19,
+	0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x12,
+	0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x58, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x5f, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4f, 0x6c, 0x64, 0x65, 0x73, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x6e, 0x65, 0x77, 0x65,
+	0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x4e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x5f, 0x72,
+	0x75, 0x6c, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x07, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x37, 0x0a, 0x0a, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x22, 0xea, 0x02, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
+	0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x01, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63,
+	0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x12, 0x14, 0x0a,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x64, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x79, 0x6d, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x79, 0x6d, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x4b, 0x65, 0x79, 0x12, 0x11, 0x0a, 0x04, 0x74, 0x6f, 0x5f, 0x78, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x74, 0x6f, 0x58, 0x12, 0x11, 0x0a, 0x04, 0x74, 0x6f, 0x5f, 0x79, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x6f, 0x59, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x5f, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x12, 0x52, 0x07, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x59, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f,
+	0x78, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x12, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x58,
+	0x22, 0x49, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x43, 0x0a, 0x04, 0x43,
+	0x68, 0x61, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x22, 0x94, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0a, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4c, 0x0a, 0x06, 0x43, 0x65, 0x6e, 0x73, 0x75,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x74, 0x69, 0x63, 0x6b, 0x12, 0x2e, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x43, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xad, 0x01, 0x0a, 0x0b, 0x43, 0x65, 0x6e, 0x73, 0x75, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x12, 0x52, 0x02, 0x64, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x78, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x12, 0x52, 0x02, 0x64, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x31, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x28,
+	0x0a, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x09, 0x54, 0x69, 0x63,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f,
+	0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x69,
+	0x72, 0x74, 0x68, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x62, 0x69, 0x72, 0x74,
+	0x68, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x61, 0x74, 0x68, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x64, 0x65, 0x61, 0x74, 0x68, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x22, 0x38, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x07, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x28, 0x0a, 0x04, 0x52, 0x4c, 0x45, 0x73, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x6c, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x52, 0x4c, 0x45, 0x52, 0x04, 0x72, 0x6c, 0x65, 0x73, 0x22, 0x5b, 0x0a, 0x03, 0x52,
+	0x4c, 0x45, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x2a, 0x9d, 0x01, 0x0a, 0x0b, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x47, 0x49,
+	0x53, 0x54, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52,
+	0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x4f, 0x52, 0x4c, 0x44,
+	0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4d, 0x4d, 0x41,
+	0x4e, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45,
+	0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x4c, 0x4f, 0x47, 0x10, 0x05,
+	0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4c, 0x45, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10,
+	0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x54, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10,
+	0x07, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x45, 0x4e, 0x53, 0x55, 0x53, 0x10, 0x08, 0x12, 0x09, 0x0a,
+	0x05, 0x53, 0x54, 0x41, 0x54, 0x53, 0x10, 0x09, 0x2a, 0xb0, 0x02, 0x0a, 0x0b, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x41, 0x52, 0x4b,
+	0x5f, 0x43, 0x45, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x4c, 0x41, 0x43, 0x45,
+	0x5f, 0x52, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x4f, 0x47, 0x47, 0x4c, 0x45,
+	0x5f, 0x50, 0x41, 0x55, 0x53, 0x45, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x4f, 0x53, 0x54,
+	0x5f, 0x43, 0x48, 0x41, 0x54, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4c, 0x45, 0x41, 0x52,
+	0x5f, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x54, 0x45, 0x50,
+	0x5f, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x45, 0x50, 0x5f,
+	0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x4a, 0x55, 0x4d,
+	0x50, 0x5f, 0x54, 0x4f, 0x5f, 0x54, 0x49, 0x43, 0x4b, 0x10, 0x07, 0x12, 0x14, 0x0a, 0x10, 0x52,
+	0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x49, 0x5a, 0x45, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f, 0x4e, 0x10,
+	0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x41, 0x49, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x10, 0x09, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x4f, 0x47, 0x47, 0x4c, 0x45, 0x5f, 0x52, 0x45, 0x56,
+	0x45, 0x52, 0x53, 0x45, 0x10, 0x0a, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x53, 0x49, 0x5a, 0x45,
+	0x10, 0x0b, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4f, 0x50, 0x59, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f,
+	0x4e, 0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x55, 0x54, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f,
+	0x4e, 0x10, 0x0d, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x53, 0x54, 0x45, 0x10, 0x0e, 0x12, 0x0f,
+	0x0a, 0x0b, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f, 0x4e, 0x10, 0x0f, 0x12,
+	0x10, 0x0a, 0x0c, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f, 0x4e, 0x10,
+	0x10, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x4f, 0x52, 0x4b, 0x10, 0x11, 0x2a, 0x38, 0x0a, 0x0c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x47,
+	0x45, 0x4e, 0x45, 0x52, 0x49, 0x43, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x00,
+	0x12, 0x13, 0x0a, 0x0f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x49, 0x43, 0x5f, 0x46, 0x41, 0x49, 0x4c,
+	0x55, 0x52, 0x45, 0x10, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -811,20 +1619,26 @@ func file_message_proto_rawDescGZIP() []byte {
 }
 
 var file_message_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_message_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_message_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_message_proto_goTypes = []interface{}{
-	(MessageType)(0),   // 0: message.MessageType
-	(CommandType)(0),   // 1: message.CommandType
-	(ResponseCode)(0),  // 2: message.ResponseCode
-	(*Message)(nil),    // 3: message.Message
-	(*Player)(nil),     // 4: message.Player
-	(*WorldData)(nil),  // 5: message.WorldData
-	(*ServerData)(nil), // 6: message.ServerData
-	(*Command)(nil),    // 7: message.Command
-	(*Response)(nil),   // 8: message.Response
-	(*Chat)(nil),       // 9: message.Chat
-	(*RLEs)(nil),       // 10: message.RLEs
-	(*RLE)(nil),        // 11: message.RLE
+	(MessageType)(0),      // 0: message.MessageType
+	(CommandType)(0),      // 1: message.CommandType
+	(ResponseCode)(0),     // 2: message.ResponseCode
+	(*Message)(nil),       // 3: message.Message
+	(*Player)(nil),        // 4: message.Player
+	(*WorldData)(nil),     // 5: message.WorldData
+	(*ServerData)(nil),    // 6: message.ServerData
+	(*Command)(nil),       // 7: message.Command
+	(*Response)(nil),      // 8: message.Response
+	(*Chat)(nil),          // 9: message.Chat
+	(*Stabilization)(nil), // 10: message.Stabilization
+	(*Census)(nil),        // 11: message.Census
+	(*CensusEntry)(nil),   // 12: message.CensusEntry
+	(*Stats)(nil),         // 13: message.Stats
+	(*TickStats)(nil),     // 14: message.TickStats
+	(*ColorCount)(nil),    // 15: message.ColorCount
+	(*RLEs)(nil),          // 16: message.RLEs
+	(*RLE)(nil),           // 17: message.RLE
 }
 var file_message_proto_depIdxs = []int32{
 	0,  // 0: message.Message.type:type_name -> message.MessageType
@@ -832,12 +1646,15 @@ var file_message_proto_depIdxs = []int32{
 	1,  // 2: message.Command.type:type_name -> message.CommandType
 	2,  // 3: message.Response.code:type_name -> message.ResponseCode
 	4,  // 4: message.Chat.player:type_name -> message.Player
-	11, // 5: message.RLEs.rles:type_name -> message.RLE
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	12, // 5: message.Census.entries:type_name -> message.CensusEntry
+	14, // 6: message.Stats.ticks:type_name -> message.TickStats
+	15, // 7: message.TickStats.colors:type_name -> message.ColorCount
+	17, // 8: message.RLEs.rles:type_name -> message.RLE
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_message_proto_init() }
@@ -931,7 +1748,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RLEs); i {
+			switch v := v.(*Stabilization); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -943,6 +1760,78 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Census); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CensusEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TickStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColorCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RLEs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RLE); i {
 			case 0:
 				return &v.state
@@ -961,7 +1850,7 @@ func file_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_message_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   9,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
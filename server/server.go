@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"github.com/denverquane/golife/proto/message"
 	"github.com/denverquane/golife/simulation"
@@ -9,6 +10,8 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,34 +24,85 @@ const DEBUG_BROADCAST_NON_REGISTERED = true
 const WORLD_HEIGHT = 400
 const WORLD_WIDTH = 750
 
+//how often a full copy of the world is kept in the history, in between the generations that only keep their changes
+const HISTORY_KEYFRAME_INTERVAL = 60
+
 var upgrader = websocket.Upgrader{} // use default options
 
 type Player struct {
 	name  string
 	color uint32
+	//the owner of the player's cells in ownership mode
+	id uint32
 }
 
+//cellColor is what the player's cells hold: their color, or the player's ID in ownership mode
+func (player Player) cellColor() uint32 {
+	if _, ok := colorRule.(simulation.OwnershipColors); ok {
+		return simulation.OwnedCell(player.id)
+	}
+	return player.color
+}
+
+//the ID of the next player to connect, starting from 1 as owner 0 is nobody
+var nextPlayerID uint32 = 1
+
 var clients = make(map[*websocket.Conn]Player)
 var clientsLock = sync.Mutex{}
 
 type BroadcastType int
 
 type BroadcastMsg struct {
-	Btype  BroadcastType
-	Paused bool
-	Client *websocket.Conn
+	Btype         BroadcastType
+	Paused        bool
+	Client        *websocket.Conn
+	Stabilization *message.Stabilization
+	Census        *simulation.Census
+	Stats         *simulation.TickStats
+	//the world message, marshalled on the simulation goroutine so the broadcaster never reads the world while it's being
+	//ticked, edited, resized or forked
+	World []byte
 }
 
 const (
 	PLAYERS    BroadcastType = 0
 	WORLD      BroadcastType = 1
 	FIRST_DATA BroadcastType = 3
+	STABILIZED BroadcastType = 4
+	CENSUS     BroadcastType = 5
+	//the world was resized or forked, so every client needs it in full again
+	RESIZED BroadcastType = 6
+)
+
+//what the server does once the world becomes periodic, besides telling the clients
+const (
+	STABLE_ANNOUNCE = "announce"
+	STABLE_PAUSE    = "pause"
+	STABLE_RESET    = "reset"
 )
 
 var SimulationChannel = make(chan simulation.SimulatorMessage)
 var BroadcastChannel = make(chan BroadcastMsg)
 
+//JoinChannel takes the clients that just registered, for the simulation goroutine to send them the full world
+var JoinChannel = make(chan *websocket.Conn)
+
 var addr = flag.String("addr", ":5000", "http service address")
+var topology = flag.String("topology", "bounded", "how the edges of the world join: bounded, torus, klein, or cross")
+var workers = flag.Uint("workers", uint(runtime.NumCPU()), "number of workers ticking the world")
+var stripes = flag.Bool("stripes", false, "split the world between the workers in stripes of rows, instead of tiles")
+var history = flag.Uint("history", 600, "number of past generations kept so a paused world can be rewound, or 0 to keep none")
+var onStable = flag.String("stable", STABLE_ANNOUNCE, "what to do once the world becomes periodic: announce, pause, or reset (clear the board)")
+var stablePeriod = flag.Uint("stable-period", 300, "longest period checked for when detecting a periodic world, or 0 to not check")
+var censusInterval = flag.Uint("census", 60, "number of ticks between censuses of the objects in the world sent to clients, or 0 to take none")
+var statsTicks = flag.Uint("stats", 600, "number of ticks of population and color statistics kept and sent to clients, or 0 to keep none")
+var colors = flag.String("colors", "rgb", "how cells are colored as they're born and survive: "+strings.Join(simulation.ColorRuleNames, ", ")+" (ownership has cells belong to the player who placed, or for births the majority of whose cells bred, them)")
+
+//colorRule is parsed from the colors flag when the world is created
+var colorRule simulation.ColorRule
+var adminKey = flag.String("admin-key", "", "key clients send with admin commands, like resizing the world; admin commands are refused when empty")
+var engineKind = flag.String("engine", simulation.PACKED_ENGINE, "engine running the world: "+strings.Join(simulation.EngineNames, ", ")+" (packed runs as dense for rules or colors it can't pack; sparse is unbounded, without history, stabilization detection, censuses or stats)")
+var rule = flag.String("rule", simulation.CONWAY_RULE, "rule to run, in B/S or S/B notation (B36/S23, 23/36, B2-a/S12, B2/S/C3, ...), a Larger than Life rule (R5,C0,M1,S34..58,B34..45,NM), a named rule (Wireworld, or a rule table in ./data), a block rule (Critters, BBM, Tron or a 16 entry table like M15,1,2,...,14,0), or an elementary rule (W30, W110)")
 
 //TODO consider that RLEs are stored in RAM... could get large
 var RleMap = make(map[string]simulation.RLE)
@@ -67,6 +121,15 @@ func main() {
 				split := strings.Split(v.Name(), ".")
 				RleMap[split[0]] = rle
 			}
+		} else if strings.HasSuffix(v.Name(), ".rule") {
+			//rule tables can then be run by name with -rule
+			tableRule, err := simulation.LoadRuleFile("./data/" + v.Name())
+			if err == nil {
+				err = simulation.RegisterRule(tableRule)
+			}
+			if err != nil {
+				log.Printf("%s: %v", v.Name(), err)
+			}
 		}
 
 	}
@@ -74,22 +137,90 @@ func main() {
 	flag.Parse()
 	log.SetFlags(0)
 
-	Run(addr)
+	Run(addr, rule, topology)
 }
 
-func Run(addr *string) {
-	var GlobalWorld simulation.World
-	GlobalWorld = simulation.NewConwayWorld(WORLD_HEIGHT, WORLD_WIDTH)
-	go simulationWorker(&GlobalWorld, 60, SimulationChannel)
-	go broadcastWorker(&GlobalWorld, BroadcastChannel)
+func Run(addr *string, rule *string, topology *string) {
+	engine, err := simulation.NewEngine(*engineKind, WORLD_HEIGHT, WORLD_WIDTH, *rule)
+	if err != nil {
+		log.Fatal(err)
+	}
+	colorRule, err = simulation.ParseColorRule(*colors)
+	if err != nil {
+		log.Fatal(err)
+	}
+	engine.SetColorRule(colorRule)
+	if *onStable != STABLE_ANNOUNCE && *onStable != STABLE_PAUSE && *onStable != STABLE_RESET {
+		log.Fatalf("unknown action %s for a periodic world", *onStable)
+	}
+	switch world := engine.(type) {
+	case *simulation.World:
+		setUpWorld(world, topology)
+	case *simulation.SparseWorld:
+		world.SetWorkers(uint32(*workers))
+		log.Printf("Running rule %s on an unbounded world\n", world.GetRule())
+	}
+	go simulationWorker(engine, 60, SimulationChannel)
+	go broadcastWorker(BroadcastChannel)
 
 	http.HandleFunc("/ws", wsHandler)
 	log.Fatal(http.ListenAndServe(*addr, nil))
 }
 
+//setUpWorld applies the flags that only apply to fixed-size worlds
+func setUpWorld(world *simulation.World, topology *string) {
+	worldTopology, err := simulation.ParseTopology(*topology)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = world.SetTopology(worldTopology)
+	if err != nil {
+		log.Fatal(err)
+	}
+	partitioning := simulation.TILES
+	if *stripes {
+		partitioning = simulation.ROW_STRIPES
+	}
+	world.SetWorkers(uint32(*workers), partitioning)
+	enableTracking(world)
+	if *censusInterval > 0 {
+		_, err = world.TakeCensus()
+		if err != nil {
+			log.Println(err)
+			*censusInterval = 0
+		}
+	}
+	log.Printf("Running rule %s on a %s world\n", world.GetRule(), worldTopology)
+}
+
+//enableTracking turns on the history, stabilization detection and stats that the flags ask for
+func enableTracking(world *simulation.World) {
+	var err error
+	if *history > 0 {
+		err = world.EnableHistory(uint32(*history), HISTORY_KEYFRAME_INTERVAL)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *stablePeriod > 0 {
+		err = world.EnableStabilizationDetection(uint32(*stablePeriod))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *statsTicks > 0 {
+		err = world.EnableStats(uint32(*statsTicks))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
 const AVERAGE_WINDOW = 100
 
-func simulationWorker(world *simulation.World, targetFps int64, msgChan <-chan simulation.SimulatorMessage) {
+func simulationWorker(engine simulation.Engine, targetFps int64, msgChan <-chan simulation.SimulatorMessage) {
+	//the features only fixed-size worlds have are skipped when this is nil
+	world, _ := engine.(*simulation.World)
 	timesCount := 0
 	timesTotal := 0.0
 	msPerFrame := (1.0 / float64(targetFps)) * 1000.0
@@ -98,26 +229,134 @@ func simulationWorker(world *simulation.World, targetFps int64, msgChan <-chan s
 
 	//GlobalWorld.PlaceRLEAtCoords(RleMap["pufferfish"], 100, 150, simulation.ALIVE_FULL)
 	paused := false
+	//set while a world with a reversible block rule is running backward
+	reversed := false
+	//the regions the players copied or cut, by their ID
+	clipboards := make(map[uint32]simulation.Pattern)
 	for {
 		select {
+		case client := <-JoinChannel:
+			marshalled, err := engine.ToFullProtoBytes()
+			if err != nil {
+				log.Printf("Error in marshalling world: %s\n", err)
+			} else {
+				BroadcastChannel <- BroadcastMsg{Btype: FIRST_DATA, Client: client, World: marshalled}
+			}
 		case msg := <-msgChan:
 			switch msg.Type {
 			case simulation.TOGGLE_PAUSE:
 				paused = !paused
 			case simulation.MARK_CELL:
 				if paused {
-					world.MarkAliveColor(msg.Y, msg.X, msg.Color)
+					//alive cells of named rules are in the color of their state
+					cell, _ := engine.StateCell(1, msg.Color)
+					y, x := cellCoords(msg)
+					if world != nil && world.IsElementary() {
+						//cells are marked in the current generation of elementary rules, whichever row was clicked
+						height, _ := world.GetDims()
+						y = int64(height) - 1
+					}
+					engine.SetCell(y, x, cell)
+				}
+			case simulation.PAINT_STATE:
+				if paused {
+					cell, err := engine.StateCell(msg.State, msg.Color)
+					if err != nil {
+						log.Println(err)
+					} else {
+						y, x := cellCoords(msg)
+						engine.SetCell(y, x, cell)
+					}
 				}
 			case simulation.PLACE_RLE:
 				if paused {
 					for name, rle := range RleMap {
-						if name == msg.Info {
-							world.PlaceRLEAtCoords(rle, msg.Y, msg.X, msg.Color)
+						if name == msg.Info && world != nil && world.IsElementary() {
+							world.PlaceSeed(rle, msg.X, msg.Color)
+						} else if name == msg.Info {
+							y, x := cellCoords(msg)
+							engine.PlacePattern(rle, y, x, msg.Color)
 						}
 					}
 				}
 			case simulation.CLEAR_BOARD:
-				world.Clear()
+				engine.Clear()
+			case simulation.STATS_COLORS:
+				if world != nil {
+					world.SetStatsColors(msg.Colors)
+				}
+			case simulation.STEP_BACK:
+				if paused && world != nil && world.IsReversible() {
+					//block rules can step back past the start of the history
+					if err := world.TickBackward(); err != nil {
+						log.Println(err)
+					}
+				} else if paused && world != nil {
+					world.StepBack()
+				}
+			case simulation.COPY_REGION, simulation.CUT_REGION, simulation.PASTE, simulation.MOVE_REGION,
+				simulation.CLEAR_REGION:
+				//copying doesn't change the world, so it doesn't need it to be paused
+				if world != nil && (paused || msg.Type == simulation.COPY_REGION) {
+					if err := editRegion(world, msg, clipboards); err != nil {
+						log.Println(err)
+					}
+				}
+			case simulation.PLAYER_LEFT:
+				delete(clipboards, msg.Player)
+			case simulation.RESIZE:
+				if world != nil {
+					err := resizeWorld(world, msg)
+					if err != nil {
+						log.Println(err)
+					} else {
+						log.Printf("Resized the world to %dx%d", msg.Width, msg.Height)
+						broadcastFullWorld(engine)
+					}
+				}
+			case simulation.TOGGLE_REVERSE:
+				if world != nil && world.IsReversible() {
+					reversed = !reversed
+				}
+			case simulation.STEP_FORWARD:
+				if paused && (world == nil || !world.StepForward()) {
+					//there's no generation to go forward to, so simulate it
+					engine.Tick()
+					paused = checkStabilization(world, paused)
+				}
+			case simulation.RANDOMIZE_REGION:
+				if paused {
+					symmetry, err := simulation.ParseSymmetry(msg.Symmetry)
+					if err == nil {
+						err = randomizeRegion(engine, msg, symmetry)
+					}
+					if err != nil {
+						log.Println(err)
+					} else {
+						log.Printf("Placed a %dx%d %s soup with seed %s at (%d, %d)", msg.Width, msg.Height, symmetry,
+							msg.Info, msg.X, msg.Y)
+					}
+				}
+			case simulation.JUMP_TO_TICK:
+				if paused && world != nil {
+					err := world.JumpToTick(msg.Tick)
+					if err != nil {
+						log.Println(err)
+					}
+				}
+			case simulation.FORK:
+				if paused && world != nil {
+					err := forkWorld(world, msg.Tick)
+					if err != nil {
+						log.Println(err)
+					} else {
+						log.Printf("Forked the world from generation %d", msg.Tick)
+						broadcastFullWorld(engine)
+						//the fork's stats don't have the players' colors yet. They're sent from another goroutine, as
+						//the message comes back through this one
+						go sendPlayerColors()
+					}
+				}
 			}
 		default:
 			clientsLock.Lock()
@@ -125,14 +364,40 @@ func simulationWorker(world *simulation.World, targetFps int64, msgChan <-chan s
 			clientsLock.Unlock()
 			if !paused && numClients > 0 {
 				oldT := time.Now().UnixNano()
-				//2 = 5 workers in total
-				world.Tick(3, true)
+				if reversed {
+					if err := world.TickBackward(); err != nil {
+						//the world is back at tick 0
+						log.Println(err)
+						reversed = false
+						paused = true
+					}
+				} else {
+					engine.Tick()
+					paused = checkStabilization(world, paused)
+					if world != nil && *censusInterval > 0 && world.GetTick()%uint64(*censusInterval) == 0 {
+						takeCensus(world)
+					}
+				}
 
 				//Consider race condition of message being received AFTER another tick...
-				BroadcastChannel <- BroadcastMsg{
+				broadcast := BroadcastMsg{
 					Btype:  WORLD,
 					Paused: false,
 				}
+				var regions [][4]uint32
+				if world != nil {
+					if stats, ok := world.GetLatestStats(); ok {
+						broadcast.Stats = &stats
+					}
+					//the world also keeps track of what was edited since the last tick, which the clients haven't seen
+					regions = world.GetChangedRegions()
+				}
+				marshalled, err := marshalWorld(engine, false, regions)
+				if err != nil {
+					log.Printf("Error in marshalling world: %s\n", err)
+				}
+				broadcast.World = marshalled
+				BroadcastChannel <- broadcast
 				//log.Print(GlobalWorld.ToString())
 				tickMs := float64(time.Now().UnixNano()-oldT) / NS_PER_MS
 				timesTotal += tickMs
@@ -149,9 +414,14 @@ func simulationWorker(world *simulation.World, targetFps int64, msgChan <-chan s
 					time.Sleep(time.Duration(NS_PER_MS * (msPerFrame - tickMs)))
 				}
 			} else {
+				marshalled, err := marshalWorld(engine, true, nil)
+				if err != nil {
+					log.Printf("Error in marshalling world: %s\n", err)
+				}
 				BroadcastChannel <- BroadcastMsg{
 					Btype:  WORLD,
 					Paused: true,
+					World:  marshalled,
 				}
 				//log.Println("Simulation is paused; sleeping for 1000ms")
 				time.Sleep(time.Millisecond * 50)
@@ -160,7 +430,182 @@ func simulationWorker(world *simulation.World, targetFps int64, msgChan <-chan s
 	}
 }
 
-func broadcastWorker(world *simulation.World, broadcasts <-chan BroadcastMsg) {
+//the simulator messages of the region commands
+var regionCommands = map[message.CommandType]int{
+	message.CommandType_COPY_REGION:  simulation.COPY_REGION,
+	message.CommandType_CUT_REGION:   simulation.CUT_REGION,
+	message.CommandType_PASTE:        simulation.PASTE,
+	message.CommandType_MOVE_REGION:  simulation.MOVE_REGION,
+	message.CommandType_CLEAR_REGION: simulation.CLEAR_REGION,
+}
+
+//editRegion copies, cuts, pastes, moves or clears the region of the message, using the clipboard of its player
+func editRegion(world *simulation.World, msg simulation.SimulatorMessage, clipboards map[uint32]simulation.Pattern) error {
+	mode := simulation.MERGE_OVERWRITE
+	if msg.Info != "" {
+		var err error
+		mode, err = simulation.ParseMergeMode(msg.Info)
+		if err != nil {
+			return err
+		}
+	}
+	switch msg.Type {
+	case simulation.COPY_REGION, simulation.CUT_REGION:
+		copyRegion := world.CopyRegion
+		if msg.Type == simulation.CUT_REGION {
+			copyRegion = world.CutRegion
+		}
+		pattern, err := copyRegion(msg.Y, msg.X, msg.Height, msg.Width)
+		if err != nil {
+			return err
+		}
+		clipboards[msg.Player] = pattern
+	case simulation.PASTE:
+		pattern, ok := clipboards[msg.Player]
+		if !ok {
+			return errors.New("there's nothing to paste")
+		}
+		return world.PastePattern(pattern, msg.Y, msg.X, mode)
+	case simulation.MOVE_REGION:
+		return world.MoveRegion(msg.Y, msg.X, msg.Height, msg.Width, msg.ToY, msg.ToX, mode)
+	case simulation.CLEAR_REGION:
+		return world.ClearRegion(msg.Y, msg.X, msg.Height, msg.Width)
+	}
+	return nil
+}
+
+//forkWorld replaces the world with its fork from the tick, so the clients carry on from there in a new timeline. The
+//fork's history and stats start over from the tick. The world is replaced in place, so this has to run on the
+//simulation goroutine, which the broadcaster only gets marshalled copies of the world from
+func forkWorld(world *simulation.World, tick uint64) error {
+	fork, err := world.Fork(tick)
+	if err != nil {
+		return err
+	}
+	world.Close()
+	*world = fork
+	enableTracking(world)
+	return nil
+}
+
+//resizeWorld resizes the world to the size of the message, anchored at the center unless the message names an anchor.
+//Like forkWorld, it has to run on the simulation goroutine
+func resizeWorld(world *simulation.World, msg simulation.SimulatorMessage) error {
+	anchor := simulation.ANCHOR_CENTER
+	if msg.Info != "" {
+		var err error
+		anchor, err = simulation.ParseAnchor(msg.Info)
+		if err != nil {
+			return err
+		}
+	}
+	return world.Resize(msg.Height, msg.Width, anchor)
+}
+
+//cellCoords returns the coordinates of the message in the world. The client's coordinates are relative to the origin
+//of the frame it was sent, which is only ever moved by unbounded worlds
+func cellCoords(msg simulation.SimulatorMessage) (int64, int64) {
+	return msg.OriginY + int64(msg.Y), msg.OriginX + int64(msg.X)
+}
+
+//randomizeRegion places a soup in the region of the message, in whichever coordinates the engine uses
+func randomizeRegion(engine simulation.Engine, msg simulation.SimulatorMessage, symmetry simulation.Symmetry) error {
+	switch world := engine.(type) {
+	case *simulation.World:
+		return world.RandomizeRegion(msg.Y, msg.X, msg.Height, msg.Width, msg.Info, msg.Density, symmetry, msg.Color)
+	case *simulation.SparseWorld:
+		y, x := cellCoords(msg)
+		return world.RandomizeRegion(y, x, msg.Height, msg.Width, msg.Info, msg.Density, symmetry, msg.Color)
+	}
+	return errors.New("soups can't be placed on this engine")
+}
+
+//checkStabilization tells the clients when the world was just found to be periodic, and pauses or resets it if the
+//server is set to. Returns whether the simulation is now paused. Only fixed-size worlds detect stabilization, so the
+//world can be nil
+func checkStabilization(world *simulation.World, paused bool) bool {
+	if world == nil {
+		return paused
+	}
+	stabilization, ok := world.GetStabilization()
+	if !ok || stabilization.DetectedAt != world.GetTick() {
+		return paused
+	}
+	action := *onStable
+	if action == STABLE_RESET && stabilization.Population == 0 {
+		//there's nothing left to clear
+		action = STABLE_ANNOUNCE
+	}
+	log.Printf("Stabilized at generation %d with period %d and %d live cells; action: %s\n", stabilization.Tick,
+		stabilization.Period, stabilization.Population, action)
+	BroadcastChannel <- BroadcastMsg{
+		Btype: STABILIZED,
+		Stabilization: &message.Stabilization{
+			Tick:       stabilization.Tick,
+			Period:     stabilization.Period,
+			DetectedAt: stabilization.DetectedAt,
+			Population: stabilization.Population,
+			Action:     action,
+		},
+	}
+	switch action {
+	case STABLE_PAUSE:
+		return true
+	case STABLE_RESET:
+		world.Clear()
+	}
+	return paused
+}
+
+//censusSlot holds a token while a census is being taken, so only one is taken at a time
+var censusSlot = make(chan struct{}, 1)
+
+//takeCensus takes the census of a copy of the world in its own goroutine, as classifying new objects takes far longer
+//than a tick, and sends it to the clients once it's done. The census is skipped while the last one is still running
+func takeCensus(world *simulation.World) {
+	select {
+	case censusSlot <- struct{}{}:
+	default:
+		return
+	}
+	copied := world.CensusCopy()
+	go func() {
+		defer func() { <-censusSlot }()
+		census, err := copied.TakeCensus()
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		BroadcastChannel <- BroadcastMsg{
+			Btype:  CENSUS,
+			Census: &census,
+		}
+	}()
+}
+
+//marshalWorld marshals the world for the clients, with only the regions of it that changed when they're given
+func marshalWorld(engine simulation.Engine, paused bool, regions [][4]uint32) ([]byte, error) {
+	if world, ok := engine.(*simulation.World); ok && regions != nil {
+		return world.ToChangedProtoBytes(regions, paused)
+	}
+	return engine.ToMinProtoBytes(paused)
+}
+
+//broadcastFullWorld has the broadcaster send every client the full world message, with the dimensions and rule of the
+//world, after it was resized or forked
+func broadcastFullWorld(engine simulation.Engine) {
+	marshalled, err := engine.ToFullProtoBytes()
+	if err != nil {
+		log.Printf("Error in marshalling world: %s\n", err)
+		return
+	}
+	BroadcastChannel <- BroadcastMsg{Btype: RESIZED, World: marshalled}
+}
+
+func broadcastWorker(broadcasts <-chan BroadcastMsg) {
+	//the latest census, and the stats of the last ticks, sent to clients as they join
+	var census *simulation.Census
+	stats := make([]simulation.TickStats, 0)
 	for {
 		select {
 		case msg := <-broadcasts:
@@ -168,26 +613,39 @@ func broadcastWorker(world *simulation.World, broadcasts <-chan BroadcastMsg) {
 			case PLAYERS:
 				broadcastPlayers()
 			case WORLD:
-				broadcastWorld(world, msg.Paused)
+				broadcastWorld(msg.World)
+				if msg.Stats != nil {
+					stats = append(stats, *msg.Stats)
+					if uint(len(stats)) > *statsTicks {
+						stats = stats[1:]
+					}
+					broadcastStats([]simulation.TickStats{*msg.Stats})
+				}
 			case FIRST_DATA:
-				sendFirstWorldMessage(msg.Client, world)
+				sendFirstWorldMessage(msg.Client, msg.World)
 				sendRLEs(msg.Client)
+				if census != nil {
+					sendCensus(msg.Client, census)
+				}
+				if len(stats) > 0 {
+					sendStats(msg.Client, stats)
+				}
+			case STABILIZED:
+				broadcastStabilization(msg.Stabilization)
+			case CENSUS:
+				census = msg.Census
+				broadcastCensus(census)
+			case RESIZED:
+				broadcastWorld(msg.World)
 			}
 		}
 	}
 }
 
-func sendFirstWorldMessage(client *websocket.Conn, world *simulation.World) {
-	marshalled, err := world.ToFullProtoBytes()
-
+func sendFirstWorldMessage(client *websocket.Conn, marshalled []byte) {
+	err := client.WriteMessage(websocket.BinaryMessage, marshalled)
 	if err != nil {
-		log.Printf("Error in marshalling world: %s\n", err)
-	} else {
-		err := client.WriteMessage(websocket.BinaryMessage, marshalled)
-		if err != nil {
-			log.Println(err)
-
-		}
+		log.Println(err)
 	}
 }
 
@@ -210,23 +668,106 @@ func sendRLEs(client *websocket.Conn) {
 	}
 }
 
-func broadcastWorld(world *simulation.World, paused bool) {
-	marshalled, err := world.ToMinProtoBytes(paused)
-	if err != nil {
-		log.Printf("Error in marshalling world: %s\n", err)
-	} else {
-		clientsLock.Lock()
-		for client, player := range clients {
-			if player.name != "" || DEBUG_BROADCAST_NON_REGISTERED {
-				err := client.WriteMessage(websocket.BinaryMessage, marshalled)
-				if err != nil {
-					log.Println(err)
-					delete(clients, client)
-				}
+//broadcastWorld sends the marshalled world to the clients, unless it failed to marshal
+func broadcastWorld(marshalled []byte) {
+	if marshalled == nil {
+		return
+	}
+	clientsLock.Lock()
+	for client, player := range clients {
+		if player.name != "" || DEBUG_BROADCAST_NON_REGISTERED {
+			err := client.WriteMessage(websocket.BinaryMessage, marshalled)
+			if err != nil {
+				log.Println(err)
+				delete(clients, client)
 			}
 		}
-		clientsLock.Unlock()
 	}
+	clientsLock.Unlock()
+}
+
+func sendCensus(client *websocket.Conn, census *simulation.Census) {
+	marshalled, err := census.ToProtoBytes()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	err = client.WriteMessage(websocket.BinaryMessage, marshalled)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func broadcastCensus(census *simulation.Census) {
+	marshalled, err := census.ToProtoBytes()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	clientsLock.Lock()
+	for client := range clients {
+		err := client.WriteMessage(websocket.BinaryMessage, marshalled)
+		if err != nil {
+			log.Println(err)
+			delete(clients, client)
+		}
+	}
+	clientsLock.Unlock()
+}
+
+func sendStats(client *websocket.Conn, stats []simulation.TickStats) {
+	marshalled, err := simulation.StatsToProtoBytes(stats)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	err = client.WriteMessage(websocket.BinaryMessage, marshalled)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func broadcastStats(stats []simulation.TickStats) {
+	marshalled, err := simulation.StatsToProtoBytes(stats)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	clientsLock.Lock()
+	for client := range clients {
+		err := client.WriteMessage(websocket.BinaryMessage, marshalled)
+		if err != nil {
+			log.Println(err)
+			delete(clients, client)
+		}
+	}
+	clientsLock.Unlock()
+}
+
+func broadcastStabilization(stabilization *message.Stabilization) {
+	stabilizationMarshalled, err := proto.Marshal(stabilization)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	msg := message.Message{
+		Type:    message.MessageType_STABILIZED,
+		Content: stabilizationMarshalled,
+	}
+	marshalled, err := proto.Marshal(&msg)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	clientsLock.Lock()
+	for client := range clients {
+		err := client.WriteMessage(websocket.BinaryMessage, marshalled)
+		if err != nil {
+			log.Println(err)
+			delete(clients, client)
+		}
+	}
+	clientsLock.Unlock()
 }
 
 func broadcastPlayers() {
@@ -236,6 +777,7 @@ func broadcastPlayers() {
 		serverData.Players = append(serverData.Players, &message.Player{
 			Name:  v.name,
 			Color: v.color,
+			Id:    v.id,
 		})
 	}
 	clientsLock.Unlock()
@@ -264,6 +806,22 @@ func broadcastPlayers() {
 	clientsLock.Unlock()
 }
 
+//sendPlayerColors has the stats count the live cells by the colors of the registered players
+func sendPlayerColors() {
+	colors := make([]uint32, 0)
+	clientsLock.Lock()
+	for _, player := range clients {
+		if player.name != "" {
+			colors = append(colors, player.cellColor())
+		}
+	}
+	clientsLock.Unlock()
+	SimulationChannel <- simulation.SimulatorMessage{
+		Type:   simulation.STATS_COLORS,
+		Colors: colors,
+	}
+}
+
 func wsHandler(w http.ResponseWriter, r *http.Request) {
 	//TODO security, fix this once deployed
 	upgrader.CheckOrigin = func(r *http.Request) bool {
@@ -278,17 +836,25 @@ func wsHandler(w http.ResponseWriter, r *http.Request) {
 	clients[c] = Player{
 		name:  "",
 		color: 0,
+		id:    nextPlayerID,
 	}
+	nextPlayerID++
 	clientsLock.Unlock()
 
 	c.SetCloseHandler(func(code int, text string) error {
 		log.Printf("Client disconnected with code %d and text: %s", code, text)
 		clientsLock.Lock()
+		id := clients[c].id
 		delete(clients, c)
 		clientsLock.Unlock()
 		BroadcastChannel <- BroadcastMsg{
 			Btype: PLAYERS,
 		}
+		sendPlayerColors()
+		SimulationChannel <- simulation.SimulatorMessage{
+			Type:   simulation.PLAYER_LEFT,
+			Player: id,
+		}
 		return nil
 	})
 
@@ -318,8 +884,17 @@ func wsHandler(w http.ResponseWriter, r *http.Request) {
 					//TODO verify name/color aren't taken
 					log.Printf("Registering %s with color %d\n", regMsg.Name, regMsg.Color)
 					clientsLock.Lock()
-					clients[c] = Player{name: regMsg.Name, color: regMsg.Color}
-					err := c.WriteMessage(websocket.BinaryMessage, data)
+					player := Player{name: regMsg.Name, color: regMsg.Color, id: clients[c].id}
+					clients[c] = player
+					//the echo tells the client which ID is theirs
+					regMsg.Id = player.id
+					echo, err := proto.Marshal(&regMsg)
+					if err == nil {
+						echo, err = proto.Marshal(&message.Message{Type: message.MessageType_REGISTER, Content: echo})
+					}
+					if err == nil {
+						err = c.WriteMessage(websocket.BinaryMessage, echo)
+					}
 					clientsLock.Unlock()
 					if err != nil {
 						log.Printf("Error echoing registration message to %s: %s\n", regMsg.Name, err)
@@ -329,10 +904,8 @@ func wsHandler(w http.ResponseWriter, r *http.Request) {
 					BroadcastChannel <- BroadcastMsg{
 						Btype: PLAYERS,
 					}
-					BroadcastChannel <- BroadcastMsg{
-						Btype:  FIRST_DATA,
-						Client: c,
-					}
+					sendPlayerColors()
+					JoinChannel <- c
 				}
 			case message.MessageType_COMMAND:
 				cmdMsg := message.Command{}
@@ -349,28 +922,113 @@ func wsHandler(w http.ResponseWriter, r *http.Request) {
 						player := clients[c]
 						//TODO Here we validate the parameters of the msg
 						SimulationChannel <- simulation.SimulatorMessage{
-							Type:  simulation.MARK_CELL,
-							X:     cmdMsg.X,
-							Y:     cmdMsg.Y,
-							Color: player.color,
+							Type:    simulation.MARK_CELL,
+							X:       cmdMsg.X,
+							Y:       cmdMsg.Y,
+							OriginX: cmdMsg.OriginX,
+							OriginY: cmdMsg.OriginY,
+							Color:   player.cellColor(),
 						}
-						log.Printf("Marking cell at (%d, %d) with color %32b", cmdMsg.X, cmdMsg.Y, player.color)
+						log.Printf("Marking cell at (%d, %d) with color %32b", cmdMsg.X, cmdMsg.Y, player.cellColor())
 
 					case message.CommandType_PLACE_RLE:
 						player := clients[c]
 						//TODO Here we validate the parameters of the msg
 						log.Println("Received RLE")
 						SimulationChannel <- simulation.SimulatorMessage{
-							Type:  simulation.PLACE_RLE,
-							X:     cmdMsg.X,
-							Y:     cmdMsg.Y,
-							Color: player.color,
-							Info:  cmdMsg.Text,
+							Type:    simulation.PLACE_RLE,
+							X:       cmdMsg.X,
+							Y:       cmdMsg.Y,
+							OriginX: cmdMsg.OriginX,
+							OriginY: cmdMsg.OriginY,
+							Color:   player.cellColor(),
+							Info:    cmdMsg.Text,
 						}
 					case message.CommandType_CLEAR_BOARD:
 						SimulationChannel <- simulation.SimulatorMessage{
 							Type: simulation.CLEAR_BOARD,
 						}
+					case message.CommandType_STEP_BACK:
+						SimulationChannel <- simulation.SimulatorMessage{
+							Type: simulation.STEP_BACK,
+						}
+					case message.CommandType_STEP_FORWARD:
+						SimulationChannel <- simulation.SimulatorMessage{
+							Type: simulation.STEP_FORWARD,
+						}
+					case message.CommandType_TOGGLE_REVERSE:
+						SimulationChannel <- simulation.SimulatorMessage{
+							Type: simulation.TOGGLE_REVERSE,
+						}
+					case message.CommandType_RANDOMIZE_REGION:
+						player := clients[c]
+						seed := cmdMsg.Text
+						if seed == "" {
+							//a seed is still needed so the soup can be shared, so make one up
+							seed = strconv.FormatInt(time.Now().UnixNano(), 36)
+						}
+						symmetry := cmdMsg.Symmetry
+						if symmetry == "" {
+							symmetry = simulation.C1.String()
+						}
+						SimulationChannel <- simulation.SimulatorMessage{
+							Type:     simulation.RANDOMIZE_REGION,
+							X:        cmdMsg.X,
+							Y:        cmdMsg.Y,
+							OriginX:  cmdMsg.OriginX,
+							OriginY:  cmdMsg.OriginY,
+							Color:    player.cellColor(),
+							Info:     seed,
+							Height:   cmdMsg.Height,
+							Width:    cmdMsg.Width,
+							Density:  float64(cmdMsg.Density),
+							Symmetry: symmetry,
+						}
+					case message.CommandType_PAINT_STATE:
+						player := clients[c]
+						SimulationChannel <- simulation.SimulatorMessage{
+							Type:    simulation.PAINT_STATE,
+							X:       cmdMsg.X,
+							Y:       cmdMsg.Y,
+							OriginX: cmdMsg.OriginX,
+							OriginY: cmdMsg.OriginY,
+							Color:   player.cellColor(),
+							State:   cmdMsg.State,
+						}
+					case message.CommandType_COPY_REGION, message.CommandType_CUT_REGION, message.CommandType_PASTE,
+						message.CommandType_MOVE_REGION, message.CommandType_CLEAR_REGION:
+						SimulationChannel <- simulation.SimulatorMessage{
+							Type:   regionCommands[cmdMsg.Type],
+							X:      cmdMsg.X,
+							Y:      cmdMsg.Y,
+							Height: cmdMsg.Height,
+							Width:  cmdMsg.Width,
+							ToX:    cmdMsg.ToX,
+							ToY:    cmdMsg.ToY,
+							Player: clients[c].id,
+							Info:   cmdMsg.Text,
+						}
+					case message.CommandType_RESIZE:
+						if *adminKey == "" || cmdMsg.AdminKey != *adminKey {
+							log.Println("Refused to resize the world without the admin key")
+						} else {
+							SimulationChannel <- simulation.SimulatorMessage{
+								Type:   simulation.RESIZE,
+								Height: cmdMsg.Height,
+								Width:  cmdMsg.Width,
+								Info:   cmdMsg.Text,
+							}
+						}
+					case message.CommandType_JUMP_TO_TICK:
+						SimulationChannel <- simulation.SimulatorMessage{
+							Type: simulation.JUMP_TO_TICK,
+							Tick: cmdMsg.Tick,
+						}
+					case message.CommandType_FORK:
+						SimulationChannel <- simulation.SimulatorMessage{
+							Type: simulation.FORK,
+							Tick: cmdMsg.Tick,
+						}
 					}
 				}
 			default:
@@ -0,0 +1,116 @@
+package simulation
+
+//the largest height and width of the regions that are tracked for changes
+const REGION_SIZE = 32
+
+//region is a part of a worker's tile. A region only needs to be recomputed if one of its cells, or one of their
+//neighbors, changed in the last tick; otherwise every cell would come out the same as in the last tick, which the data
+//buffer (holding the generation before the current one) already has
+type region struct {
+	minY uint32
+	minX uint32
+	maxY uint32
+	maxX uint32
+	//the regions whose cells neighbor the cells of this one, where the perimeter is region len(world.regions)
+	neighbors []int
+}
+
+//computeRegions splits every tile into regions, and finds their neighbors
+func (world *World) computeRegions() {
+	world.regions = nil
+	world.tileRegions = make([][]int, len(world.tiles))
+	for i, tile := range world.tiles {
+		rows := (tile[2] - tile[0] + REGION_SIZE - 1) / REGION_SIZE
+		columns := (tile[3] - tile[1] + REGION_SIZE - 1) / REGION_SIZE
+		for _, stripe := range rowStripes(tile[2]-tile[0], rows) {
+			for _, column := range rowStripes(tile[3]-tile[1], columns) {
+				world.tileRegions[i] = append(world.tileRegions[i], len(world.regions))
+				world.regions = append(world.regions, region{
+					minY: tile[0] + stripe[0],
+					minX: tile[1] + column[0],
+					maxY: tile[0] + stripe[1],
+					maxX: tile[1] + column[1],
+				})
+			}
+		}
+	}
+
+	perimeter := len(world.regions)
+	for i := range world.regions {
+		r := &world.regions[i]
+		for j, other := range world.regions {
+			//the regions touch if they overlap once one of them is grown by a cell on every side
+			if i != j && other.minY <= r.maxY && other.maxY >= r.minY && other.minX <= r.maxX && other.maxX >= r.minX {
+				r.neighbors = append(r.neighbors, j)
+			}
+		}
+		if r.minY == 1 || r.minX == 1 || r.maxY == world.height-1 || r.maxX == world.width-1 {
+			r.neighbors = append(r.neighbors, perimeter)
+		}
+	}
+	world.changed = make([]bool, len(world.regions)+1)
+	world.dirty = make([]bool, len(world.regions)+1)
+	world.edits = make([]bool, len(world.regions)+1)
+	world.allDirty = true
+}
+
+//computeDirty finds the regions that need to be recomputed this tick, and resets the changes for the workers to fill in.
+//The regions edited since the last tick start out changed, as the clients haven't seen the edits yet
+func (world *World) computeDirty() {
+	for i := range world.regions {
+		dirty := world.allDirty || world.changed[i] || world.edits[i]
+		for _, neighbor := range world.regions[i].neighbors {
+			dirty = dirty || world.changed[neighbor] || world.edits[neighbor]
+		}
+		world.dirty[i] = dirty
+	}
+	for i := range world.changed {
+		world.changed[i] = world.edits[i]
+		world.edits[i] = false
+	}
+	world.allDirty = false
+}
+
+//tickRegions recomputes the dirty regions of the tile, and records which of them changed
+func (world *World) tickRegions(tile int) {
+	for _, r := range world.tileRegions[tile] {
+		if world.dirty[r] {
+			bounds := world.regions[r]
+			world.changed[r] = world.innerWorker(bounds.minY, bounds.minX, bounds.maxY, bounds.maxX) || world.changed[r]
+		}
+	}
+}
+
+//GetChangedRegions returns the rectangles (as minY, minX, maxY, maxX, with the maximums exclusive) holding every cell
+//that changed in the last tick or were edited since the tick before it, so only they need to be sent to clients. Edits
+//of the whole world and engines that don't track changes mark the whole world as changed
+func (world *World) GetChangedRegions() [][4]uint32 {
+	if world.changedAll || world.editedAll || len(world.regions) == 0 {
+		return [][4]uint32{{0, 0, world.height, world.width}}
+	}
+	changed := make([][4]uint32, 0)
+	for i, r := range world.regions {
+		if world.changed[i] || world.edits[i] {
+			changed = append(changed, [4]uint32{r.minY, r.minX, r.maxY, r.maxX})
+		}
+	}
+	if perimeter := len(world.regions); world.changed[perimeter] || world.edits[perimeter] {
+		changed = append(changed,
+			[4]uint32{0, 0, 1, world.width},
+			[4]uint32{world.height - 1, 0, world.height, world.width},
+			[4]uint32{1, 0, world.height - 1, 1},
+			[4]uint32{1, world.width - 1, world.height - 1, world.width},
+		)
+	}
+	return changed
+}
+
+//GetActiveRegionCount returns how many of the regions were recomputed in the last tick, out of the total
+func (world *World) GetActiveRegionCount() (active int, total int) {
+	for i := range world.regions {
+		if world.dirty[i] {
+			active++
+		}
+	}
+	return active, len(world.regions)
+}
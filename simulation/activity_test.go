@@ -0,0 +1,190 @@
+package simulation
+
+import (
+	"github.com/denverquane/golife/proto/message"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestWorld_TickActiveRegions(t *testing.T) {
+	for topology := range TopologyNames {
+		for _, colors := range []ColorRule{RGBBlend{}, MajorityColors{}} {
+			world, _ := NewWorldWithRule(100, 90, CONWAY_RULE)
+			world.SetTopology(topology)
+			reference, _ := NewWorldWithRule(100, 90, CONWAY_RULE)
+			reference.SetTopology(topology)
+			//the packed tick doesn't track regions, so turn it off to test the regular workers with every color rule
+			world.packed, reference.packed = nil, nil
+			world.SetColorRule(colors)
+			reference.SetColorRule(colors)
+			randomSoup(&world, 3, []uint32{0xFF000000, 0x00FF0000})
+			randomSoup(&reference, 3, []uint32{0xFF000000, 0x00FF0000})
+
+			for i := 0; i < 300; i++ {
+				if i == 200 {
+					world.PlaceRLEAtCoords(glider, 40, 40, FULL)
+					reference.PlaceRLEAtCoords(glider, 40, 40, FULL)
+				}
+				reference.allDirty = true
+				world.Tick()
+				reference.Tick()
+				for y := uint32(0); y < world.height; y++ {
+					for x := uint32(0); x < world.width; x++ {
+						if (*world.data)[y][x] != (*reference.data)[y][x] {
+							t.Fatalf("%s, tick %d: cell (%d, %d) is %08x, expected %08x", topology, i, y, x,
+								(*world.data)[y][x], (*reference.data)[y][x])
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestWorld_GetChangedRegions(t *testing.T) {
+	world := NewConwayWorld(100, 100)
+	block := RLE{width: 2, height: 2, data: [][]bool{{true, true}, {true, true}}}
+	world.PlaceRLEAtCoords(block, 10, 10, FULL)
+	world.PlaceRLEAtCoords(glider, 50, 50, FULL)
+	//only the regions of the edited cells are changed
+	if changed := world.GetChangedRegions(); len(changed) != 2 || changed[0][0] > 10 || changed[0][2] <= 11 ||
+		changed[1][0] > 50 || changed[1][2] <= 52 {
+		t.Fatalf("expected the regions of the block and the glider to be changed after placing them, got %v", changed)
+	}
+	world.Tick()
+	world.MarkAlive(0, 30)
+	if changed := world.GetChangedRegions(); changed[len(changed)-4] != [4]uint32{0, 0, 1, 100} {
+		t.Fatalf("expected the perimeter to be changed after editing it, got %v", changed)
+	}
+	world.Clear()
+	if changed := world.GetChangedRegions(); len(changed) != 1 || changed[0] != [4]uint32{0, 0, 100, 100} {
+		t.Fatalf("expected the whole world to be changed after clearing it, got %v", changed)
+	}
+	world.Tick()
+	if changed := world.GetChangedRegions(); len(changed) != 1 || changed[0] != [4]uint32{0, 0, 100, 100} {
+		t.Fatalf("expected the whole world to be changed in the tick after clearing it, got %v", changed)
+	}
+	world.PlaceRLEAtCoords(block, 10, 10, FULL)
+	world.PlaceRLEAtCoords(glider, 50, 50, FULL)
+
+	//the block keeps changing until its cells have fully aged
+	for i := 0; i < 130; i++ {
+		world.Tick()
+	}
+	for _, r := range world.GetChangedRegions() {
+		if r[0] <= 11 && r[2] > 10 && r[1] <= 11 && r[3] > 10 {
+			t.Errorf("the region %v of the block changed", r)
+		}
+	}
+	changed := false
+	for _, r := range world.GetChangedRegions() {
+		changed = changed || (r[0] <= 82 && r[2] > 82 && r[1] <= 82 && r[3] > 82)
+	}
+	if !changed {
+		t.Errorf("the region of the glider didn't change")
+	}
+	active, total := world.GetActiveRegionCount()
+	if active == 0 || active > 9 {
+		t.Errorf("expected only the regions around the glider to be active, got %d of %d", active, total)
+	}
+}
+
+func TestWorld_ToChangedProtoBytes(t *testing.T) {
+	world := NewConwayWorld(100, 100)
+	world.PlaceRLEAtCoords(RLE{width: 2, height: 2, data: [][]bool{{true, true}, {true, true}}}, 10, 10, FULL)
+	world.PlaceRLEAtCoords(glider, 50, 50, FULL)
+	//what a client has, kept up to date from the frames: the whole world, and then only the regions that changed
+	client := make(DataGrid, 100)
+	for y := range client {
+		client[y] = make([]uint32, 100)
+	}
+	for i := 0; i < 150; i++ {
+		//cells are painted between some of the ticks, on the perimeter too, as players do
+		if i%10 == 5 {
+			world.MarkAliveColor(uint32(i)%100, 70, 0xFF000000)
+			world.SetCell(99, int64(i)%100, FULL)
+		}
+		//the erased block doesn't change in the tick, but the client still has it
+		if i == 140 {
+			for _, cell := range [][2]int64{{10, 10}, {10, 11}, {11, 10}, {11, 11}} {
+				world.SetCell(cell[0], cell[1], DEAD)
+			}
+		}
+		world.Tick()
+		marshalled, err := world.ToChangedProtoBytes(world.GetChangedRegions(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := message.Message{}
+		worldMsg := message.WorldData{}
+		if err := proto.Unmarshal(marshalled, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if err := proto.Unmarshal(msg.Content, &worldMsg); err != nil {
+			t.Fatal(err)
+		}
+		if !worldMsg.Partial {
+			t.Fatalf("tick %d: expected the frame to be marked partial", i+1)
+		}
+		//the dead runs are expanded, and the cells are taken by the rectangles in order
+		cells := make([]uint32, 0)
+		for _, value := range worldMsg.Data {
+			if value&ALIVE_BIT != 0 {
+				cells = append(cells, value)
+			} else {
+				cells = append(cells, make([]uint32, value>>1)...)
+			}
+		}
+		regions := worldMsg.ChangedRegions
+		if (i == 149 || i%10 == 5) && len(cells) >= 100*100 {
+			t.Errorf("tick %d: expected only the cells around the glider and the edits to be sent", i+1)
+		}
+		for r := 0; r < len(regions); r += 4 {
+			for y := regions[r]; y < regions[r]+regions[r+2]; y++ {
+				for x := regions[r+1]; x < regions[r+1]+regions[r+3]; x++ {
+					client[y][x] = cells[0] &^ ALIVE_BIT
+					cells = cells[1:]
+				}
+			}
+		}
+		for y := range client {
+			for x := range client[y] {
+				if client[y][x] != (*world.data)[y][x]&^ALIVE_BIT {
+					t.Fatalf("tick %d: expected the client to have %08x at (%d, %d), got %08x", i+1,
+						(*world.data)[y][x], y, x, client[y][x])
+				}
+			}
+		}
+	}
+}
+
+//a Gosper glider gun on an otherwise empty world, so the world is mostly quiet
+func gunWorld(b *testing.B) World {
+	gun, err := LoadRLE("../data/glider.rle")
+	if err != nil {
+		b.Fatal(err)
+	}
+	world := NewConwayWorld(1000, 1000)
+	world.PlaceRLEAtCoords(gun, 100, 100, FULL)
+	for i := 0; i < 300; i++ {
+		world.Tick()
+	}
+	return world
+}
+
+func BenchmarkWorld_TickActiveRegions(b *testing.B) {
+	world := gunWorld(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.Tick()
+	}
+}
+
+func BenchmarkWorld_TickAllRegions(b *testing.B) {
+	world := gunWorld(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.allDirty = true
+		world.Tick()
+	}
+}
@@ -0,0 +1,520 @@
+package simulation
+
+import (
+	"errors"
+	"github.com/denverquane/golife/proto/message"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type ObjectType int
+
+const (
+	STILL_LIFE ObjectType = 0
+	OSCILLATOR ObjectType = 1
+	SPACESHIP  ObjectType = 2
+	//objects that didn't repeat within MAX_CENSUS_PERIOD generations on their own, or were too large to try
+	UNCLASSIFIED ObjectType = 3
+)
+
+var ObjectTypeNames = map[ObjectType]string{
+	STILL_LIFE:   "still life",
+	OSCILLATOR:   "oscillator",
+	SPACESHIP:    "spaceship",
+	UNCLASSIFIED: "unclassified",
+}
+
+func (objectType ObjectType) String() string {
+	return ObjectTypeNames[objectType]
+}
+
+//the longest period, and the largest population, of the objects that the census tries to classify
+const MAX_CENSUS_PERIOD = 60
+const MAX_CENSUS_POPULATION = 500
+
+//the most object shapes whose classification is remembered between censuses
+const MAX_CENSUS_CACHE = 10000
+
+//ObjectNames are the common names of some of the objects of Conway's Game of Life, by apgcode
+var ObjectNames = map[string]string{
+	"xs4_33":       "block",
+	"xs4_252":      "tub",
+	"xs5_253":      "boat",
+	"xs6_356":      "ship",
+	"xs6_696":      "beehive",
+	"xs7_2596":     "loaf",
+	"xs8_6996":     "pond",
+	"xp2_7":        "blinker",
+	"xp2_7e":       "toad",
+	"xp2_318c":     "beacon",
+	"xp15_4r4z4r4": "pentadecathlon",
+	"xq4_153":      "glider",
+	"xq4_6frc":     "lightweight spaceship",
+}
+
+//CensusEntry is a kind of object, and how many of them were found
+type CensusEntry struct {
+	//the apgcode of the object, such as xs4_33 for a block or xq4_153 for a glider, or empty for unclassified objects
+	Code string
+	//the object's common name, if it has one
+	Name   string
+	Type   ObjectType
+	Period uint32
+	//how far a spaceship moves every period
+	DY    int64
+	DX    int64
+	Count uint32
+}
+
+//Census counts the objects of a world by kind, with the most common first
+type Census struct {
+	Tick    uint64
+	Entries []CensusEntry
+}
+
+//Speed describes how fast a spaceship moves, such as c/4 diagonal for a glider or c/2 orthogonal for a lightweight
+//spaceship, or is empty for objects that don't move
+func (entry CensusEntry) Speed() string {
+	dy, dx := entry.DY, entry.DX
+	if dy < 0 {
+		dy = -dy
+	}
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < dx {
+		dy, dx = dx, dy
+	}
+	if dy == 0 {
+		return ""
+	}
+	if dx != 0 && dx != dy {
+		return "(" + strconv.FormatInt(dy, 10) + "," + strconv.FormatInt(dx, 10) + ")c/" + strconv.Itoa(int(entry.Period))
+	}
+	direction := "orthogonal"
+	if dx == dy {
+		direction = "diagonal"
+	}
+	//reduce the fraction of c
+	a, b := dy, int64(entry.Period)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	speed := "c/" + strconv.FormatInt(int64(entry.Period)/a, 10)
+	if dy/a != 1 {
+		speed = strconv.FormatInt(dy/a, 10) + speed
+	}
+	return speed + " " + direction
+}
+
+//objectClass is how an object was classified, with the displacement relative to the shape it was classified from
+type objectClass struct {
+	code       string
+	objectType ObjectType
+	period     uint32
+	dy         int64
+	dx         int64
+}
+
+//TakeCensus splits the live cells of the world into objects of nearby cells, and classifies each of them by
+//running it on its own. Only two-state rules where every orientation and position behaves the same are supported
+func (world *World) TakeCensus() (Census, error) {
+	if world.ltl != nil || world.states > 2 {
+		return Census{}, errors.New("the census only supports two-state rules")
+	}
+	if world.stateRule != nil {
+		return Census{}, errors.New("the census doesn't support named or table rules")
+	}
+	if world.margolus != nil || world.elementary != nil {
+		return Census{}, errors.New("the census doesn't support block or elementary rules")
+	}
+	if world.neighborhoodMasks[0] != world.neighborhoodMasks[1] {
+		return Census{}, errors.New("the census doesn't support hexagonal rules")
+	}
+	if world.deadRulesMapping[0] {
+		return Census{}, errors.New("the census doesn't support rules with B0")
+	}
+	if world.censusClasses == nil {
+		world.censusClasses = make(map[string]objectClass)
+	} else if len(world.censusClasses) > MAX_CENSUS_CACHE {
+		//emptied in place, as copies of the world share it
+		for key := range world.censusClasses {
+			delete(world.censusClasses, key)
+		}
+	}
+	//the objects are classified one after the other on the same unbounded world
+	sparse := &SparseWorld{
+		cellRules: world.cellRules,
+		rule:      world.rule,
+		chunks:    make(map[chunkCoords]*chunk),
+		workers:   1,
+	}
+	defer sparse.Close()
+	//only which cells are alive matters, so their colors aren't blended
+	sparse.colors = MajorityColors{}
+
+	counts := make(map[objectClass]uint32)
+	visited := make([]bool, world.height*world.width)
+	for y := uint32(0); y < world.height; y++ {
+		for x := uint32(0); x < world.width; x++ {
+			if visited[y*world.width+x] || !isAliveBool((*world.data)[y][x]) {
+				continue
+			}
+			cells := world.findObject(y, x, visited)
+			var class objectClass
+			if len(cells) > MAX_CENSUS_POPULATION {
+				class = objectClass{objectType: UNCLASSIFIED}
+			} else {
+				key := wechsler(cells)
+				var ok bool
+				class, ok = world.censusClasses[key]
+				if !ok {
+					class = classifyObject(sparse, cells)
+					world.censusClasses[key] = class
+				}
+			}
+			//spaceships are counted together whichever way they're heading
+			class.dy, class.dx = 0, 0
+			counts[class]++
+		}
+	}
+
+	census := Census{Tick: world.tick}
+	for class, count := range counts {
+		entry := CensusEntry{
+			Code:   class.code,
+			Name:   ObjectNames[class.code],
+			Type:   class.objectType,
+			Period: class.period,
+			Count:  count,
+		}
+		if class.objectType == SPACESHIP {
+			//the speed doesn't depend on the direction, so report it in the direction the apgcode's phase is heading
+			entry.DY, entry.DX = world.spaceshipDisplacement(sparse, class.code)
+		}
+		census.Entries = append(census.Entries, entry)
+	}
+	sort.Slice(census.Entries, func(i, j int) bool {
+		if census.Entries[i].Count != census.Entries[j].Count {
+			return census.Entries[i].Count > census.Entries[j].Count
+		}
+		return census.Entries[i].Code < census.Entries[j].Code
+	})
+	return census, nil
+}
+
+//CensusCopy copies the cells of the world into a world that the census can be taken of in another goroutine, while
+//this one keeps ticking. The copy shares the classes of the objects this world has seen, so only one census of a world
+//and its copies can be taken at a time
+func (world *World) CensusCopy() World {
+	census := world.emptyCopy()
+	for y, row := range *world.data {
+		copy((*census.data)[y], row)
+	}
+	census.tick = world.tick
+	if world.censusClasses == nil {
+		world.censusClasses = make(map[string]objectClass)
+	}
+	census.censusClasses = world.censusClasses
+	return census
+}
+
+//objectOffsets are the cells within 2 cells of a cell, which share a neighbor with it. Live cells this close belong to
+//the same object, so objects like the beacon or the lightweight spaceship, which aren't all connected, stay whole (but
+//still lifes that close together count as one object)
+var objectOffsets = func() [][2]int64 {
+	offsets := make([][2]int64, 0, 24)
+	for y := int64(-2); y <= 2; y++ {
+		for x := int64(-2); x <= 2; x++ {
+			if y != 0 || x != 0 {
+				offsets = append(offsets, [2]int64{y, x})
+			}
+		}
+	}
+	return offsets
+}()
+
+//findObject returns the live cells within 2 cells of (y, x), and of each other, marking them as visited. The
+//coordinates aren't wrapped, so an object crossing the edges of a wrapping topology keeps its shape, and they start at
+//(0, 0)
+func (world *World) findObject(y, x uint32, visited []bool) [][2]int64 {
+	visited[y*world.width+x] = true
+	cells := [][2]int64{{int64(y), int64(x)}}
+	for i := 0; i < len(cells); i++ {
+		for _, offset := range objectOffsets {
+			ny, nx := cells[i][0]+offset[0], cells[i][1]+offset[1]
+			wy, wx, ok := world.wrapCoords(ny, nx)
+			if !ok || visited[wy*world.width+wx] || !isAliveBool((*world.data)[wy][wx]) {
+				continue
+			}
+			visited[wy*world.width+wx] = true
+			cells = append(cells, [2]int64{ny, nx})
+		}
+	}
+	return normalizeCells(cells)
+}
+
+//normalizeCells moves the cells so their bounding box starts at (0, 0), and sorts them by row and then column
+func normalizeCells(cells [][2]int64) [][2]int64 {
+	minY, minX := cells[0][0], cells[0][1]
+	for _, cell := range cells {
+		if cell[0] < minY {
+			minY = cell[0]
+		}
+		if cell[1] < minX {
+			minX = cell[1]
+		}
+	}
+	normalized := make([][2]int64, len(cells))
+	for i, cell := range cells {
+		normalized[i] = [2]int64{cell[0] - minY, cell[1] - minX}
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		if normalized[i][0] != normalized[j][0] {
+			return normalized[i][0] < normalized[j][0]
+		}
+		return normalized[i][1] < normalized[j][1]
+	})
+	return normalized
+}
+
+func sameCells(a, b [][2]int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//liveCells returns the live cells of the sparse world, and the top-left corner of their bounding box
+func (world *SparseWorld) liveCells() ([][2]int64, int64, int64) {
+	cells := make([][2]int64, 0)
+	for coords, c := range world.chunks {
+		for y := int64(0); y < CHUNK_SIZE; y++ {
+			for x := int64(0); x < CHUNK_SIZE; x++ {
+				if isAliveBool(c[y][x]) {
+					cells = append(cells, [2]int64{coords.y*CHUNK_SIZE + y, coords.x*CHUNK_SIZE + x})
+				}
+			}
+		}
+	}
+	if len(cells) == 0 {
+		return cells, 0, 0
+	}
+	minY, minX := cells[0][0], cells[0][1]
+	for _, cell := range cells {
+		if cell[0] < minY {
+			minY = cell[0]
+		}
+		if cell[1] < minX {
+			minX = cell[1]
+		}
+	}
+	return normalizeCells(cells), minY, minX
+}
+
+//classifyObject runs the object on its own on the sparse world, until it comes back to the same shape, and finds its
+//apgcode from the phase (and orientation) with the shortest code
+func classifyObject(sparse *SparseWorld, cells [][2]int64) objectClass {
+	sparse.Clear()
+	sparse.tick = 0
+	for _, cell := range cells {
+		sparse.SetCell(cell[0], cell[1], ALIVE_NEW)
+	}
+
+	code := canonicalWechsler(cells)
+	for period := 1; period <= MAX_CENSUS_PERIOD; period++ {
+		sparse.Tick()
+		phase, dy, dx := sparse.liveCells()
+		if len(phase) == 0 || len(phase) > MAX_CENSUS_POPULATION {
+			break
+		}
+		if sameCells(phase, cells) {
+			class := objectClass{period: uint32(period), dy: dy, dx: dx}
+			prefix := "xp"
+			if dy != 0 || dx != 0 {
+				class.objectType = SPACESHIP
+				prefix = "xq"
+			} else if period == 1 {
+				class.objectType = STILL_LIFE
+				prefix = "xs"
+			} else {
+				class.objectType = OSCILLATOR
+			}
+			if class.objectType == STILL_LIFE {
+				class.code = prefix + strconv.Itoa(len(cells)) + "_" + code
+			} else {
+				class.code = prefix + strconv.Itoa(period) + "_" + code
+			}
+			return class
+		}
+		code = shorterWechsler(code, canonicalWechsler(phase))
+	}
+	return objectClass{objectType: UNCLASSIFIED}
+}
+
+//spaceshipDisplacement returns how far the spaceship with the apgcode moves every period, heading the way the phase in
+//its apgcode does
+func (world *World) spaceshipDisplacement(sparse *SparseWorld, code string) (int64, int64) {
+	separator := strings.IndexByte(code, '_')
+	cells := wechslerCells(code[separator+1:])
+	class, ok := world.censusClasses[wechsler(cells)]
+	if !ok {
+		class = classifyObject(sparse, cells)
+		world.censusClasses[wechsler(cells)] = class
+	}
+	return class.dy, class.dx
+}
+
+//the digits of the extended Wechsler format; columns use the first 32, and runs of 4 or more blank columns all 36
+const WECHSLER_DIGITS = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+//wechsler encodes the cells in the extended Wechsler format: the rows are split into strips of 5, each column of a
+//strip is a digit with the top row as its lowest bit, and the strips are separated by "z". Runs of blank columns are
+//shortened to "w" (2), "x" (3) or "y" and a digit (4 and more), and blank columns at the end of a strip are dropped
+func wechsler(cells [][2]int64) string {
+	height, width := int64(0), int64(0)
+	for _, cell := range cells {
+		if cell[0]+1 > height {
+			height = cell[0] + 1
+		}
+		if cell[1]+1 > width {
+			width = cell[1] + 1
+		}
+	}
+	strips := make([][]byte, (height+4)/5)
+	for i := range strips {
+		strips[i] = make([]byte, width)
+	}
+	for _, cell := range cells {
+		strips[cell[0]/5][cell[1]] |= 1 << uint(cell[0]%5)
+	}
+
+	var code strings.Builder
+	for i, strip := range strips {
+		if i > 0 {
+			code.WriteByte('z')
+		}
+		blanks := 0
+		for _, column := range strip {
+			if column == 0 {
+				blanks++
+				continue
+			}
+			writeBlanks(&code, blanks)
+			blanks = 0
+			code.WriteByte(WECHSLER_DIGITS[column])
+		}
+	}
+	return code.String()
+}
+
+func writeBlanks(code *strings.Builder, blanks int) {
+	for blanks >= 40 {
+		code.WriteString("yz")
+		blanks -= 39
+	}
+	switch {
+	case blanks == 1:
+		code.WriteByte('0')
+	case blanks == 2:
+		code.WriteByte('w')
+	case blanks == 3:
+		code.WriteByte('x')
+	case blanks >= 4:
+		code.WriteByte('y')
+		code.WriteByte(WECHSLER_DIGITS[blanks-4])
+	}
+}
+
+//wechslerCells decodes cells from the extended Wechsler format
+func wechslerCells(code string) [][2]int64 {
+	cells := make([][2]int64, 0)
+	strip, column := int64(0), int64(0)
+	for i := 0; i < len(code); i++ {
+		switch code[i] {
+		case 'z':
+			strip++
+			column = 0
+		case 'w':
+			column += 2
+		case 'x':
+			column += 3
+		case 'y':
+			i++
+			column += 4 + int64(strings.IndexByte(WECHSLER_DIGITS, code[i]))
+		default:
+			digit := strings.IndexByte(WECHSLER_DIGITS, code[i])
+			for bit := int64(0); bit < 5; bit++ {
+				if digit&(1<<uint(bit)) != 0 {
+					cells = append(cells, [2]int64{strip*5 + bit, column})
+				}
+			}
+			column++
+		}
+	}
+	return normalizeCells(cells)
+}
+
+//canonicalWechsler returns the shortest code of the cells in any of their 8 orientations
+func canonicalWechsler(cells [][2]int64) string {
+	code := ""
+	for orientation := 0; orientation < 8; orientation++ {
+		oriented := make([][2]int64, len(cells))
+		for i, cell := range cells {
+			y, x := cell[0], cell[1]
+			if orientation&1 != 0 {
+				x = -x
+			}
+			if orientation&2 != 0 {
+				y = -y
+			}
+			if orientation&4 != 0 {
+				y, x = x, y
+			}
+			oriented[i] = [2]int64{y, x}
+		}
+		code = shorterWechsler(code, wechsler(normalizeCells(oriented)))
+	}
+	return code
+}
+
+//shorterWechsler picks the shorter of two codes, or the one that comes first if they're the same length
+func shorterWechsler(a, b string) string {
+	if a == "" || len(b) < len(a) || (len(b) == len(a) && b < a) {
+		return b
+	}
+	return a
+}
+
+//ToProtoBytes marshals the census as a CENSUS message
+func (census Census) ToProtoBytes() ([]byte, error) {
+	censusMsg := message.Census{Tick: census.Tick}
+	for _, entry := range census.Entries {
+		censusMsg.Entries = append(censusMsg.Entries, &message.CensusEntry{
+			Code:   entry.Code,
+			Name:   entry.Name,
+			Type:   entry.Type.String(),
+			Period: entry.Period,
+			Dy:     entry.DY,
+			Dx:     entry.DX,
+			Count:  entry.Count,
+			Speed:  entry.Speed(),
+		})
+	}
+	censusMarshalled, err := proto.Marshal(&censusMsg)
+	if err != nil {
+		return nil, err
+	}
+	msg := message.Message{
+		Type:    message.MessageType_CENSUS,
+		Content: censusMarshalled,
+	}
+	return proto.Marshal(&msg)
+}
@@ -0,0 +1,145 @@
+package simulation
+
+import (
+	"testing"
+)
+
+//patternRLE makes an RLE from rows of O (alive) and . (dead)
+func patternRLE(name string, rows ...string) RLE {
+	rle := RLE{name: name, height: uint32(len(rows))}
+	for _, row := range rows {
+		if uint32(len(row)) > rle.width {
+			rle.width = uint32(len(row))
+		}
+	}
+	for _, row := range rows {
+		data := make([]bool, rle.width)
+		for x, c := range row {
+			data[x] = c == 'O'
+		}
+		rle.data = append(rle.data, data)
+	}
+	return rle
+}
+
+func TestWorld_TakeCensus(t *testing.T) {
+	world := NewConwayWorld(100, 100)
+	world.SetTopology(TORUS)
+	patterns := []RLE{
+		patternRLE("block", "OO", "OO"),
+		patternRLE("blinker", "OOO"),
+		patternRLE("beehive", ".OO.", "O..O", ".OO."),
+		patternRLE("toad", ".OOO", "OOO."),
+		//not all of its cells are connected
+		patternRLE("beacon", "OO..", "O...", "...O", "..OO"),
+		patternRLE("lwss", ".O..O", "O....", "O...O", "OOOO."),
+		patternRLE("pentadecathlon", "..O....O..", "OO.OOOO.OO", "..O....O.."),
+		//still changing after MAX_CENSUS_PERIOD generations
+		patternRLE("r-pentomino", ".OO", "OO.", ".O."),
+		//gliders in different phases and heading different ways
+		patternRLE("glider", ".O.", "..O", "OOO"),
+		patternRLE("glider", "O.O", ".OO", ".O."),
+		patternRLE("glider", "OOO", "O..", ".O."),
+		patternRLE("block", "OO", "OO"),
+	}
+	for i, rle := range patterns {
+		world.PlaceRLEAtCoords(rle, uint32(i/4)*30+5, uint32(i%4)*20+5, FULL)
+	}
+	//wraps around the corner of the torus
+	for _, cell := range [][2]uint32{{0, 0}, {0, 99}, {99, 0}, {99, 99}} {
+		world.MarkAlive(cell[0], cell[1])
+	}
+
+	expected := []CensusEntry{
+		{Code: "xq4_153", Name: "glider", Type: SPACESHIP, Period: 4, DY: -1, DX: 1, Count: 3},
+		{Code: "xs4_33", Name: "block", Type: STILL_LIFE, Period: 1, Count: 3},
+		{Type: UNCLASSIFIED, Count: 1},
+		{Code: "xp15_4r4z4r4", Name: "pentadecathlon", Type: OSCILLATOR, Period: 15, Count: 1},
+		{Code: "xp2_318c", Name: "beacon", Type: OSCILLATOR, Period: 2, Count: 1},
+		{Code: "xp2_7", Name: "blinker", Type: OSCILLATOR, Period: 2, Count: 1},
+		{Code: "xp2_7e", Name: "toad", Type: OSCILLATOR, Period: 2, Count: 1},
+		{Code: "xq4_6frc", Name: "lightweight spaceship", Type: SPACESHIP, Period: 4, DY: 2, Count: 1},
+		{Code: "xs6_696", Name: "beehive", Type: STILL_LIFE, Period: 1, Count: 1},
+	}
+	census, err := world.TakeCensus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(census.Entries) != len(expected) {
+		t.Fatalf("expected %d kinds of objects, got %+v", len(expected), census.Entries)
+	}
+	for i, entry := range census.Entries {
+		if entry != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], entry)
+		}
+	}
+	if speed := census.Entries[0].Speed(); speed != "c/4 diagonal" {
+		t.Errorf("expected the glider to move at c/4 diagonal, got %s", speed)
+	}
+	if speed := census.Entries[7].Speed(); speed != "c/2 orthogonal" {
+		t.Errorf("expected the lightweight spaceship to move at c/2 orthogonal, got %s", speed)
+	}
+
+	//a copy taken while the world keeps ticking has the census of the world when it was copied, and the classes it saw
+	copied := world.CensusCopy()
+	classes := len(world.censusClasses)
+	world.Tick()
+	copiedCensus, err := copied.TakeCensus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(copiedCensus.Entries) != len(expected) || copiedCensus.Tick != 0 {
+		t.Errorf("expected the copy to have the census of tick 0, got %+v at tick %d", copiedCensus.Entries,
+			copiedCensus.Tick)
+	}
+	if len(copied.censusClasses) != classes {
+		t.Errorf("expected the copy to use the %d classes already seen, got %d", classes, len(copied.censusClasses))
+	}
+
+	generations, err := NewWorldWithRule(10, 10, "B2/S/C3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generations.TakeCensus(); err == nil {
+		t.Error("expected an error taking the census of a Generations rule")
+	}
+	//a two-state table doesn't necessarily follow the rule the census would run the objects with
+	rule, err := ParseRuleTable("@RULE CensusTest\n@TABLE\nn_states:2\nneighborhood:vonNeumann\n0,1,0,0,0,1\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	defer delete(NamedRules, "censustest")
+	table, err := NewWorldWithRule(10, 10, "censustest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := table.TakeCensus(); err == nil {
+		t.Error("expected an error taking the census of a table rule")
+	}
+}
+
+func TestWechsler(t *testing.T) {
+	for _, code := range []string{"153", "4r4z4r4", "6frc", "1y31", "1zx1"} {
+		if encoded := wechsler(wechslerCells(code)); encoded != code {
+			t.Errorf("expected %s to decode and encode to itself, got %s", code, encoded)
+		}
+	}
+	//every orientation of the glider has the same canonical code
+	for _, rows := range [][]string{{".O.", "..O", "OOO"}, {".O.", "O..", "OOO"}, {"OOO", "..O", ".O."}, {"..O", "O.O", ".OO"}} {
+		rle := patternRLE("glider", rows...)
+		cells := make([][2]int64, 0)
+		for y, row := range rle.data {
+			for x, alive := range row {
+				if alive {
+					cells = append(cells, [2]int64{int64(y), int64(x)})
+				}
+			}
+		}
+		if code := canonicalWechsler(cells); code != "153" {
+			t.Errorf("expected the glider %v to have the code 153, got %s", rows, code)
+		}
+	}
+}
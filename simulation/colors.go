@@ -0,0 +1,296 @@
+package simulation
+
+import (
+	"fmt"
+	"github.com/lucasb-eyer/go-colorful"
+	"strings"
+)
+
+//ColorRule decides what the top 3 bytes of a cell (usually its color) become as it's born or survives. The neighbors
+//are the bits of the alive neighbors of the cell at (y, x) of src, as in the rest of the world
+type ColorRule interface {
+	//Born returns the newly alive cell born at (y, x) of src
+	Born(src *DataGrid, y, x uint32, neighbors byte) uint32
+	//Survive returns the cell at (y, x) of src once it has survived (and aged) a tick
+	Survive(src *DataGrid, y, x uint32, neighbors byte) uint32
+	//Blends reports whether the colors of surviving cells depend on their neighbors. Rules that don't blend can use the
+	//packed engine, and Larger than Life rules, whose neighbors don't fit in a byte, get the most common color in range
+	//as the only neighbor of a birth instead of their average color
+	Blends() bool
+	Name() string
+}
+
+//RGBBlend averages the colors of the parents of a birth, and blends survivors with their neighbors
+type RGBBlend struct{}
+
+func (RGBBlend) Born(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return src.NewCellNeighborsColorBlend(y, x, neighbors)
+}
+
+func (RGBBlend) Survive(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return src.ExistingCellNeighborsColorBlend((*src)[y][x], y, x, neighbors)
+}
+
+func (RGBBlend) Blends() bool {
+	return true
+}
+
+func (RGBBlend) Name() string {
+	return "rgb"
+}
+
+//LabBlend blends like RGBBlend, but averages the colors in the Lab color space, so blends look more even
+type LabBlend struct{}
+
+func (LabBlend) Born(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return src.neighborsColorMean(y, x, neighbors, false, colorful.Color.BlendLab)
+}
+
+func (LabBlend) Survive(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return src.neighborsColorMean(y, x, neighbors, true, colorful.Color.BlendLab)
+}
+
+func (LabBlend) Blends() bool {
+	return true
+}
+
+func (LabBlend) Name() string {
+	return "lab"
+}
+
+//HCLBlend blends like RGBBlend, but averages the colors in the HCL color space, so blends keep their saturation
+type HCLBlend struct{}
+
+func blendHcl(a colorful.Color, b colorful.Color, t float64) colorful.Color {
+	return a.BlendHcl(b, t).Clamped()
+}
+
+func (HCLBlend) Born(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return src.neighborsColorMean(y, x, neighbors, false, blendHcl)
+}
+
+func (HCLBlend) Survive(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return src.neighborsColorMean(y, x, neighbors, true, blendHcl)
+}
+
+func (HCLBlend) Blends() bool {
+	return true
+}
+
+func (HCLBlend) Name() string {
+	return "hcl"
+}
+
+//neighborsColorMean averages the colors of the alive neighbors (and the cell itself, when it survives) by blending them
+//in one at a time, returning a newly alive cell for births and an aged one for survivors
+func (dg *DataGrid) neighborsColorMean(y, x uint32, neighbors byte, survives bool,
+	blend func(colorful.Color, colorful.Color, float64) colorful.Color) uint32 {
+	mean := colorful.Color{}
+	blended := 0
+	if survives {
+		mean = colorOfCell((*dg)[y][x])
+		blended++
+	}
+	for i, offset := range neighborOffsets {
+		if neighbors>>i&1 == 0 {
+			continue
+		}
+		col := colorOfCell((*dg)[int(y)+offset[0]][int(x)+offset[1]])
+		if blended == 0 {
+			mean = col
+		} else {
+			mean = blend(mean, col, 1/float64(blended+1))
+		}
+		blended++
+	}
+	cell := newCellOfColor(mean)
+	if survives {
+		return Decay(cell&^ALIVE_NEW | (*dg)[y][x]&ALIVE_NEW)
+	}
+	return cell
+}
+
+//MajorityColors gives births the most common color of their parents, and survivors keep their color
+type MajorityColors struct{}
+
+func (MajorityColors) Born(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return (*src).NeighborsColorMajority(y, x, neighbors)
+}
+
+func (MajorityColors) Survive(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return Decay((*src)[y][x])
+}
+
+func (MajorityColors) Blends() bool {
+	return false
+}
+
+func (MajorityColors) Name() string {
+	return "majority"
+}
+
+//the colors of the two kinds of cells in Immigration, and the four of QuadLife
+var IMMIGRATION_COLORS = []uint32{0xFF000000, 0x0000FF00}
+var QUADLIFE_COLORS = []uint32{0xFF000000, 0x00FF0000, 0x0000FF00, 0xFFFF0000}
+
+//DiscreteMajority only has a few colors, like Immigration (2 colors) and QuadLife (4 colors): every cell takes the
+//palette color closest to its own, and births take the most common color of their parents. With 4 colors, a birth
+//whose 3 parents all have different colors takes the fourth color
+type DiscreteMajority struct {
+	Palette []uint32
+}
+
+func (rule DiscreteMajority) closest(cell uint32) int {
+	closest, closestDistance := 0, -1
+	for i, color := range rule.Palette {
+		distance := 0
+		for shift := uint(8); shift < 32; shift += 8 {
+			difference := int((cell>>shift)&0xFF) - int((color>>shift)&0xFF)
+			distance += difference * difference
+		}
+		if closestDistance == -1 || distance < closestDistance {
+			closest, closestDistance = i, distance
+		}
+	}
+	return closest
+}
+
+//Born picks the most common color, with ties going to the color found first going clockwise from the northwest
+func (rule DiscreteMajority) Born(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	counts := make([]int, len(rule.Palette))
+	order := make([]int, 0, len(rule.Palette))
+	for i, offset := range neighborOffsets {
+		if neighbors>>i&1 == 0 {
+			continue
+		}
+		color := rule.closest((*src)[int(y)+offset[0]][int(x)+offset[1]])
+		if counts[color] == 0 {
+			order = append(order, color)
+		}
+		counts[color]++
+	}
+	if len(rule.Palette) == 4 && len(order) == 3 && NumNeighbors(neighbors) == 3 {
+		for color := range rule.Palette {
+			if counts[color] == 0 {
+				return rule.Palette[color]&^ALIVE_NEW | ALIVE_NEW
+			}
+		}
+	}
+	if len(order) == 0 {
+		//born without any parents, with B0
+		return rule.Palette[0]&^ALIVE_NEW | ALIVE_NEW
+	}
+	best := order[0]
+	for _, color := range order {
+		if counts[color] > counts[best] {
+			best = color
+		}
+	}
+	return rule.Palette[best]&^ALIVE_NEW | ALIVE_NEW
+}
+
+func (rule DiscreteMajority) Survive(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	cell := (*src)[y][x]
+	return Decay(rule.Palette[rule.closest(cell)]&^ALIVE_NEW | cell&ALIVE_NEW)
+}
+
+func (rule DiscreteMajority) Blends() bool {
+	return false
+}
+
+func (rule DiscreteMajority) Name() string {
+	if len(rule.Palette) == 4 {
+		return "quadlife"
+	}
+	return "immigration"
+}
+
+//AgeGradient colors cells by their age, going from the Young color as they're born to the Old color once they've
+//stopped aging, whatever their parents' colors
+type AgeGradient struct {
+	Young uint32
+	Old   uint32
+}
+
+func (rule AgeGradient) Born(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return rule.Young&^ALIVE_NEW | ALIVE_NEW
+}
+
+func (rule AgeGradient) Survive(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	cell := Decay((*src)[y][x])
+	age := cell & ALIVE_NEW
+	//the lower byte goes from ALIVE_NEW down to ALIVE_BIT
+	t := float64(ALIVE_NEW-age) / float64(ALIVE_NEW-ALIVE_BIT)
+	color := colorOfCell(rule.Young).BlendRgb(colorOfCell(rule.Old), t)
+	return newCellOfColor(color)&^ALIVE_NEW | age
+}
+
+func (rule AgeGradient) Blends() bool {
+	return false
+}
+
+func (rule AgeGradient) Name() string {
+	return "age"
+}
+
+//OwnershipColors gives births the most common owner of their parents, and survivors keep their owner. See ownership.go
+type OwnershipColors struct{}
+
+func (OwnershipColors) Born(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return (*src).NeighborsOwnerMajority(y, x, neighbors)
+}
+
+func (OwnershipColors) Survive(src *DataGrid, y, x uint32, neighbors byte) uint32 {
+	return Decay((*src)[y][x])
+}
+
+func (OwnershipColors) Blends() bool {
+	return false
+}
+
+func (OwnershipColors) Name() string {
+	return "ownership"
+}
+
+//ColorRuleNames are the names of the color rules ParseColorRule knows, with their default colors
+var ColorRuleNames = []string{"rgb", "lab", "hcl", "majority", "immigration", "quadlife", "age", "ownership"}
+
+func ParseColorRule(name string) (ColorRule, error) {
+	switch strings.ToLower(name) {
+	case "rgb":
+		return RGBBlend{}, nil
+	case "lab":
+		return LabBlend{}, nil
+	case "hcl":
+		return HCLBlend{}, nil
+	case "majority":
+		return MajorityColors{}, nil
+	case "immigration":
+		return DiscreteMajority{Palette: IMMIGRATION_COLORS}, nil
+	case "quadlife":
+		return DiscreteMajority{Palette: QUADLIFE_COLORS}, nil
+	case "age":
+		return AgeGradient{Young: 0xFFFF0000, Old: 0x0000FF00}, nil
+	case "ownership":
+		return OwnershipColors{}, nil
+	}
+	return nil, fmt.Errorf("unknown color rule %s", name)
+}
+
+//SetColorRule sets how the colors of the cells change as they're born and survive. The cells already in the world keep
+//their colors until then
+func (world *World) SetColorRule(colors ColorRule) {
+	world.colors = colors
+}
+
+func (world *World) GetColorRule() ColorRule {
+	return world.colors
+}
+
+func (world *SparseWorld) SetColorRule(colors ColorRule) {
+	world.colors = colors
+}
+
+func (world *SparseWorld) GetColorRule() ColorRule {
+	return world.colors
+}
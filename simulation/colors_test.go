@@ -0,0 +1,137 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestParseColorRule(t *testing.T) {
+	for _, name := range ColorRuleNames {
+		colors, err := ParseColorRule(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if colors.Name() != name {
+			t.Errorf("expected %s to be named %s", name, colors.Name())
+		}
+	}
+	if _, err := ParseColorRule("QuadLife"); err != nil {
+		t.Error(err)
+	}
+	if _, err := ParseColorRule("cmyk"); err == nil {
+		t.Error("expected an error parsing an unknown color rule")
+	}
+}
+
+func TestDiscreteMajority_Born(t *testing.T) {
+	red, green, blue, yellow := QUADLIFE_COLORS[0], QUADLIFE_COLORS[1], QUADLIFE_COLORS[2], QUADLIFE_COLORS[3]
+	quadlife := DiscreteMajority{Palette: QUADLIFE_COLORS}
+	tests := []struct {
+		grid     DataGrid
+		expected uint32
+	}{
+		//3 different parents give the fourth color
+		{DataGrid{
+			{red | ALIVE_NEW, green | ALIVE_NEW, DEAD},
+			{DEAD, DEAD, blue | ALIVE_NEW},
+			{DEAD, DEAD, DEAD},
+		}, yellow},
+		{DataGrid{
+			{DEAD, blue | ALIVE_NEW, DEAD},
+			{DEAD, DEAD, green | ALIVE_NEW},
+			{DEAD, blue | ALIVE_NEW, DEAD},
+		}, blue},
+		//blended colors are taken as the closest color of the palette
+		{DataGrid{
+			{0xF0100001, DEAD, DEAD},
+			{0x10F01001, DEAD, DEAD},
+			{0xE0200001, DEAD, DEAD},
+		}, red},
+	}
+	for i, test := range tests {
+		neighborhood := test.grid.InnerNeighborsValue(1, 1)
+		if cell := quadlife.Born(&test.grid, 1, 1, neighborhood); cell != test.expected|ALIVE_NEW {
+			t.Errorf("test %d: expected %08x, got %08x", i, test.expected|ALIVE_NEW, cell)
+		}
+	}
+}
+
+func TestAgeGradient_Survive(t *testing.T) {
+	age := AgeGradient{Young: 0xFF000000, Old: 0x0000FF00}
+	grid := DataGrid{{DEAD, DEAD, DEAD}, {DEAD, DEAD, DEAD}, {DEAD, DEAD, DEAD}}
+	grid[1][1] = age.Born(&grid, 1, 1, 0)
+	if grid[1][1] != 0xFF0000FF {
+		t.Fatalf("expected a newly born cell to be red, got %08x", grid[1][1])
+	}
+	previousRed := uint32(0xFF)
+	for i := 0; i < 200; i++ {
+		grid[1][1] = age.Survive(&grid, 1, 1, 0)
+		red := grid[1][1] >> 24
+		if red > previousRed || !isAliveBool(grid[1][1]) {
+			t.Fatalf("tick %d: expected the cell to keep getting bluer, got %08x", i, grid[1][1])
+		}
+		previousRed = red
+	}
+	if grid[1][1] != 0x0000FF00|ALIVE_BIT {
+		t.Errorf("expected an old cell to be blue, got %08x", grid[1][1])
+	}
+}
+
+func TestColorRules_BornFromOneColor(t *testing.T) {
+	//the parents of a birth all having the same color, every blend gives it that color
+	grid := DataGrid{
+		{0x4080C0FF, DEAD, 0x4080C0FF},
+		{DEAD, DEAD, DEAD},
+		{DEAD, 0x4080C0FF, DEAD},
+	}
+	neighborhood := grid.InnerNeighborsValue(1, 1)
+	for _, colors := range []ColorRule{RGBBlend{}, LabBlend{}, HCLBlend{}, MajorityColors{}} {
+		cell := colors.Born(&grid, 1, 1, neighborhood)
+		for shift := uint(8); shift < 32; shift += 8 {
+			difference := int((cell>>shift)&0xFF) - int((0x4080C0FF>>shift)&0xFF)
+			if difference < -1 || difference > 1 {
+				t.Errorf("%s: expected a birth colored 4080c0, got %08x", colors.Name(), cell)
+				break
+			}
+		}
+	}
+}
+
+func TestWorld_ColorRules(t *testing.T) {
+	for _, rule := range []string{CONWAY_RULE, "B36/S23", "R2,C0,M1,S2..5,B3..4,NM"} {
+		for _, name := range []string{"immigration", "quadlife", "age"} {
+			colors, _ := ParseColorRule(name)
+			//rules that don't blend give the same cells on the packed engine
+			var expected [][]uint32
+			for _, packed := range []bool{true, false} {
+				world, err := NewWorldWithRule(40, 50, rule)
+				if err != nil {
+					t.Fatal(err)
+				}
+				world.SetColorRule(colors)
+				if !packed {
+					world.packed = nil
+				}
+				randomSoup(&world, 5, QUADLIFE_COLORS)
+				for i := 0; i < 30; i++ {
+					world.Tick()
+				}
+				world.Close()
+				if discrete, ok := colors.(DiscreteMajority); ok {
+					for y := uint32(0); y < world.height; y++ {
+						for x := uint32(0); x < world.width; x++ {
+							cell := (*world.data)[y][x]
+							if isAliveBool(cell) && discrete.Palette[discrete.closest(cell)] != cell&^0xFF {
+								t.Fatalf("rule %s, %s colors: cell (%d, %d) is %08x", rule, name, y, x, cell)
+							}
+						}
+					}
+				}
+				if expected == nil {
+					expected = copyData(&world)
+				} else {
+					checkData(t, &world, expected)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package simulation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//ElementaryRule is one of Wolfram's 256 elementary cellular automata, written W0 to W255 like in Golly. Its cells are
+//in a single row, and the next state of a cell depends on it and its left and right neighbors: bit 4*left + 2*cell +
+//right of the rule number is the state it becomes
+type ElementaryRule uint8
+
+//IsElementaryRule returns whether the rulestring is in the W notation of elementary rules
+func IsElementaryRule(rulestring string) bool {
+	return len(rulestring) > 1 && (rulestring[0] == 'W' || rulestring[0] == 'w') && rulestring[1] >= '0' &&
+		rulestring[1] <= '9'
+}
+
+//ParseElementaryRule parses an elementary rule in W notation, such as "W30" or "W110"
+func ParseElementaryRule(rulestring string) (ElementaryRule, error) {
+	if !IsElementaryRule(rulestring) {
+		return 0, fmt.Errorf("%s isn't an elementary rule", rulestring)
+	}
+	number, err := strconv.ParseUint(strings.TrimSpace(rulestring[1:]), 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("elementary rules are numbered from 0 to 255, got %s", rulestring[1:])
+	}
+	return ElementaryRule(number), nil
+}
+
+func (rule ElementaryRule) String() string {
+	return "W" + strconv.Itoa(int(rule))
+}
+
+//Next returns whether a cell is alive in the next generation, given whether it and its neighbors are alive now
+func (rule ElementaryRule) Next(left, cell, right bool) bool {
+	index := uint(0)
+	for _, alive := range []bool{left, cell, right} {
+		index <<= 1
+		if alive {
+			index |= 1
+		}
+	}
+	return rule>>index&1 == 1
+}
+
+//IsElementary returns whether the world runs an elementary rule, where the bottom row is the current generation and
+//the rows above it are the generations before it
+func (world *World) IsElementary() bool {
+	return world.elementary != nil
+}
+
+//tickElementary scrolls the world up by a row, as a space-time diagram, and computes the next generation of the bottom
+//row into the new bottom row. The left and right edges of the row wrap around unless the world is bounded
+func (world *World) tickElementary() {
+	for y := uint32(1); y < world.height; y++ {
+		copy((*world.dataBuffer)[y-1], (*world.data)[y])
+	}
+	world.elementaryRow()
+	world.swapBuffers()
+	world.dataChanged()
+}
+
+//elementaryRow computes the bottom row of the data buffer. Cells are colored by the color rule as if the row before
+//them was the row of their north neighbors: a cell that stays alive survives with its left and right neighbors as its
+//NW and NE neighbors, and a new cell is born from the alive cells among them, or is white when it has no parents
+func (world *World) elementaryRow() {
+	last := int64(world.height - 1)
+	grid := DataGrid{make([]uint32, 3), make([]uint32, 3), make([]uint32, 3)}
+	for x := uint32(0); x < world.width; x++ {
+		left, cell, right := world.seedCell(int64(x)-1), (*world.data)[last][x], world.seedCell(int64(x)+1)
+		next := DEAD
+		if world.elementary.Next(isAliveBool(left), isAliveBool(cell), isAliveBool(right)) {
+			grid[0][0], grid[0][1], grid[0][2], grid[1][1] = left, cell, right, cell
+			neighbors := isAlive(left) | isAlive(right)<<2
+			if isAliveBool(cell) {
+				next = world.colors.Survive(&grid, 1, 1, neighbors)
+			} else if neighbors != 0 {
+				next = world.colors.Born(&grid, 1, 1, neighbors)
+			} else {
+				next = FULL
+			}
+		}
+		(*world.dataBuffer)[last][x] = next
+	}
+}
+
+//seedCell returns the cell of the bottom row at x, wrapping around the row unless the world is bounded
+func (world *World) seedCell(x int64) uint32 {
+	if x < 0 || x >= int64(world.width) {
+		if world.topology == BOUNDED {
+			return DEAD
+		}
+		x = (x + int64(world.width)) % int64(world.width)
+	}
+	return (*world.data)[world.height-1][x]
+}
+
+//PlaceSeed places the RLE into the current generation of an elementary world, with its left edge at x. Every row of
+//the RLE is placed onto the bottom row, so a cell is placed in every column where the RLE has one. Returns false if the
+//RLE doesn't fit in a bounded world
+func (world *World) PlaceSeed(rle RLE, x, color uint32) bool {
+	if world.topology == BOUNDED && x+rle.width > world.width {
+		return false
+	}
+	last := world.height - 1
+	for yy := uint32(0); yy < rle.height; yy++ {
+		for xx := uint32(0); xx < rle.width; xx++ {
+			if cell := world.rleCell(rle, yy, xx, color); cell != DEAD {
+				(*world.data)[last][(x+xx)%world.width] = cell
+			}
+		}
+	}
+	world.edited()
+	return true
+}
@@ -0,0 +1,103 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestParseElementaryRule(t *testing.T) {
+	rule, err := ParseElementaryRule("w30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule != 30 || rule.String() != "W30" {
+		t.Errorf("expected W30, got %s", rule)
+	}
+	//rule 30 is 00011110: only the neighborhoods 100, 011, 010 and 001 become alive
+	expected := []bool{false, true, true, true, true, false, false, false}
+	for i, alive := range expected {
+		if rule.Next(i&4 != 0, i&2 != 0, i&1 != 0) != alive {
+			t.Errorf("expected neighborhood %03b of W30 to be %v", i, alive)
+		}
+	}
+	for _, rulestring := range []string{"W256", "W1a"} {
+		if _, err := ParseElementaryRule(rulestring); err == nil {
+			t.Errorf("expected an error parsing %s", rulestring)
+		}
+	}
+	if IsElementaryRule("Wireworld") {
+		t.Error("expected Wireworld not to be an elementary rule")
+	}
+	if _, err := NewSparseWorld("W110"); err == nil {
+		t.Error("expected an error running an elementary rule on an unbounded world")
+	}
+}
+
+func TestWorld_Elementary(t *testing.T) {
+	for _, topology := range []Topology{BOUNDED, TORUS} {
+		world, err := NewWorldWithRule(10, 21, "W90")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !world.IsElementary() || world.GetRule() != "W90" {
+			t.Fatalf("expected an elementary world running W90, got %s", world.GetRule())
+		}
+		world.SetTopology(topology)
+		world.SetColorRule(MajorityColors{})
+		world.SetCell(9, 10, 0xFF0000FF)
+		for i := 0; i < 7; i++ {
+			world.Tick()
+		}
+		//rule 90 draws Pascal's triangle mod 2, with the seed scrolled up to the row 7 generations before the bottom
+		for generation := 0; generation <= 7; generation++ {
+			y := int64(2 + generation)
+			for x := int64(0); x < 21; x++ {
+				k := x - 10 + int64(generation)
+				expected := k >= 0 && k%2 == 0 && k/2 <= int64(generation) && k/2&^int64(generation) == 0
+				cell := world.GetCell(y, x)
+				if isAliveBool(cell) != expected {
+					t.Fatalf("%s: expected (%d, %d) to be alive: %v", topology, y, x, expected)
+				}
+				if expected && cell&^0xFF != 0xFF000000 {
+					t.Fatalf("%s: expected the cells to be born in the color of their parents, got %08x", topology, cell)
+				}
+			}
+		}
+		for x := int64(0); x < 21; x++ {
+			if isAliveBool(world.GetCell(0, x)) || isAliveBool(world.GetCell(1, x)) {
+				t.Fatalf("%s: expected the rows before the seed to be empty", topology)
+			}
+		}
+	}
+}
+
+func TestWorld_ElementaryEdges(t *testing.T) {
+	bounded, _ := NewWorldWithRule(4, 5, "W90")
+	torus, _ := NewWorldWithRule(4, 5, "W90")
+	torus.SetTopology(TORUS)
+	for _, world := range []*World{&bounded, &torus} {
+		world.SetCell(3, 0, FULL)
+		world.Tick()
+	}
+	if !isAliveBool(bounded.GetCell(3, 1)) || isAliveBool(bounded.GetCell(3, 4)) {
+		t.Error("expected the bounded row not to wrap around")
+	}
+	if !isAliveBool(torus.GetCell(3, 1)) || !isAliveBool(torus.GetCell(3, 4)) {
+		t.Error("expected the row of the torus to wrap around")
+	}
+}
+
+func TestWorld_PlaceSeed(t *testing.T) {
+	world, _ := NewWorldWithRule(5, 10, "W30")
+	//every column of the glider has a cell in one of its rows
+	if !world.PlaceSeed(glider, 2, 0x00FF0000) {
+		t.Fatal("expected the seed to fit")
+	}
+	for x := int64(0); x < 10; x++ {
+		if isAliveBool(world.GetCell(4, x)) != (x >= 2 && x < 5) {
+			t.Fatalf("expected the glider to seed columns 2 to 4, got %08x at %d", world.GetCell(4, x), x)
+		}
+	}
+	if world.PlaceSeed(glider, 8, FULL) {
+		t.Error("expected a seed past the right edge not to fit")
+	}
+}
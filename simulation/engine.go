@@ -0,0 +1,123 @@
+package simulation
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Engine is a world of cells that can be ticked, edited and sent to clients, whatever the way it's stored and simulated.
+//The coordinates are signed so unbounded engines can use the whole plane, and bounded engines ignore the cells outside
+//of them. Features that only some engines have (like the history of a World) are found with a type assertion
+type Engine interface {
+	Tick()
+	GetTick() uint64
+	GetRule() string
+	GetStates() uint32
+	//GetCell returns the cell at (y, x), which is DEAD outside of the engine
+	GetCell(y, x int64) uint32
+	//SetCell replaces the cell at (y, x) with the cell, which can be DEAD to erase it
+	SetCell(y, x int64, cell uint32)
+	//PlacePattern places the RLE with its top-left corner at (y, x), returning false if it doesn't fit
+	PlacePattern(rle RLE, y, x int64, color uint32) bool
+	//StateCell returns the cell to place for a state of the rule, in the color if the rule's states don't have their own
+	StateCell(state, color uint32) (uint32, error)
+	Clear()
+	Snapshot() Snapshot
+	SetColorRule(colors ColorRule)
+	ToMinProtoBytes(paused bool) ([]byte, error)
+	ToFullProtoBytes() ([]byte, error)
+	//Close stops the workers of the engine
+	Close()
+}
+
+//Snapshot is a copy of every cell of an engine that isn't dead, by its [y, x] coordinates
+type Snapshot struct {
+	Tick  uint64
+	Cells map[[2]int64]uint32
+}
+
+//the kinds of engines NewEngine creates: a World that evaluates every cell, a World that also uses the packed engine
+//whenever it can (for two-state totalistic rules with a color rule that doesn't blend, as new worlds do), and an
+//unbounded SparseWorld
+const (
+	DENSE_ENGINE  = "dense"
+	PACKED_ENGINE = "packed"
+	SPARSE_ENGINE = "sparse"
+)
+
+var EngineNames = []string{DENSE_ENGINE, PACKED_ENGINE, SPARSE_ENGINE}
+
+//NewEngine creates an engine of the kind running the rule. The height and width are ignored by unbounded engines
+func NewEngine(kind string, height, width uint32, rulestring string) (Engine, error) {
+	switch strings.ToLower(kind) {
+	case DENSE_ENGINE, PACKED_ENGINE:
+		world, err := NewWorldWithRule(height, width, rulestring)
+		if err != nil {
+			return nil, err
+		}
+		if strings.ToLower(kind) == DENSE_ENGINE {
+			world.packed = nil
+		}
+		return &world, nil
+	case SPARSE_ENGINE:
+		world, err := NewSparseWorld(rulestring)
+		if err != nil {
+			return nil, err
+		}
+		return world, nil
+	}
+	return nil, fmt.Errorf("unknown engine %s", kind)
+}
+
+func (world *World) GetCell(y, x int64) uint32 {
+	if y < 0 || x < 0 || y >= int64(world.height) || x >= int64(world.width) {
+		return DEAD
+	}
+	return (*world.data)[y][x]
+}
+
+func (world *World) SetCell(y, x int64, cell uint32) {
+	if y < 0 || x < 0 || y >= int64(world.height) || x >= int64(world.width) {
+		return
+	}
+	(*world.data)[y][x] = cell
+	world.editedCell(uint32(y), uint32(x))
+}
+
+//PlacePattern is PlaceRLEAtCoords with the coordinates of an Engine, where the top-left corner has to be in the world
+func (world *World) PlacePattern(rle RLE, y, x int64, color uint32) bool {
+	if y < 0 || x < 0 || y >= int64(world.height) || x >= int64(world.width) {
+		return false
+	}
+	return world.PlaceRLEAtCoords(rle, uint32(y), uint32(x), color)
+}
+
+func (world *World) Snapshot() Snapshot {
+	snapshot := Snapshot{Tick: world.tick, Cells: make(map[[2]int64]uint32)}
+	for y := uint32(0); y < world.height; y++ {
+		for x := uint32(0); x < world.width; x++ {
+			if cell := (*world.data)[y][x]; cell != DEAD {
+				snapshot.Cells[[2]int64{int64(y), int64(x)}] = cell
+			}
+		}
+	}
+	return snapshot
+}
+
+func (world *SparseWorld) PlacePattern(rle RLE, y, x int64, color uint32) bool {
+	return world.PlaceRLEAtCoords(rle, y, x, color)
+}
+
+func (world *SparseWorld) Snapshot() Snapshot {
+	snapshot := Snapshot{Tick: world.tick, Cells: make(map[[2]int64]uint32)}
+	for coords, c := range world.chunks {
+		for y := int64(0); y < CHUNK_SIZE; y++ {
+			for x := int64(0); x < CHUNK_SIZE; x++ {
+				if c[y][x] != DEAD {
+					snapshot.Cells[[2]int64{coords.y*CHUNK_SIZE + y, coords.x*CHUNK_SIZE + x}] = c[y][x]
+				}
+			}
+		}
+	}
+	return snapshot
+}
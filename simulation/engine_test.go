@@ -0,0 +1,176 @@
+package simulation
+
+import (
+	"github.com/denverquane/golife/proto/message"
+	"google.golang.org/protobuf/proto"
+	"math/rand"
+	"testing"
+)
+
+//the engines checked by the conformance tests. They're all large enough for the patterns of the tests never to reach
+//the edges of the bounded ones
+var engineKinds = []string{DENSE_ENGINE, PACKED_ENGINE, SPARSE_ENGINE}
+
+func newTestEngine(t *testing.T, kind string, rule string) Engine {
+	engine, err := NewEngine(kind, 200, 200, rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the majority color of a birth is picked at random when there's no majority, so births aren't blended, and the
+	//tests only use one color
+	engine.SetColorRule(MajorityColors{})
+	return engine
+}
+
+func checkSnapshot(t *testing.T, kind string, snapshot Snapshot, expected Snapshot) {
+	if snapshot.Tick != expected.Tick || len(snapshot.Cells) != len(expected.Cells) {
+		t.Fatalf("%s: expected %d cells at tick %d, got %d at tick %d", kind, len(expected.Cells), expected.Tick,
+			len(snapshot.Cells), snapshot.Tick)
+	}
+	for coords, cell := range expected.Cells {
+		if snapshot.Cells[coords] != cell {
+			t.Fatalf("%s, tick %d: expected cell %v to be %08x, got %08x", kind, expected.Tick, coords, cell,
+				snapshot.Cells[coords])
+		}
+	}
+}
+
+func TestEngine_Cells(t *testing.T) {
+	for _, kind := range engineKinds {
+		engine := newTestEngine(t, kind, CONWAY_RULE)
+		if snapshot := engine.Snapshot(); len(snapshot.Cells) != 0 || engine.GetTick() != 0 {
+			t.Fatalf("%s: expected a new engine to be empty", kind)
+		}
+		engine.SetCell(10, 20, 0xFF0000FF)
+		if cell := engine.GetCell(10, 20); cell != 0xFF0000FF {
+			t.Fatalf("%s: expected the cell to be set, got %08x", kind, cell)
+		}
+		if cell := engine.GetCell(20, 10); cell != DEAD {
+			t.Fatalf("%s: expected an unset cell to be dead, got %08x", kind, cell)
+		}
+		engine.SetCell(10, 20, DEAD)
+		if len(engine.Snapshot().Cells) != 0 {
+			t.Fatalf("%s: expected the cell to be erased", kind)
+		}
+
+		if !engine.PlacePattern(glider, 50, 50, 0xFF000000) {
+			t.Fatalf("%s: expected the glider to fit", kind)
+		}
+		engine.Clear()
+		if len(engine.Snapshot().Cells) != 0 {
+			t.Fatalf("%s: expected the engine to be empty once cleared", kind)
+		}
+		engine.Close()
+	}
+}
+
+func TestEngine_Glider(t *testing.T) {
+	for _, kind := range engineKinds {
+		engine := newTestEngine(t, kind, CONWAY_RULE)
+		engine.PlacePattern(glider, 50, 50, 0xFF000000)
+		start := engine.Snapshot()
+		for i := 0; i < 40; i++ {
+			engine.Tick()
+		}
+		//a glider moves one cell diagonally every 4 generations, and every cell is born again as it moves
+		expected := Snapshot{Tick: 40, Cells: make(map[[2]int64]uint32)}
+		for coords := range start.Cells {
+			moved := [2]int64{coords[0] + 10, coords[1] + 10}
+			expected.Cells[moved] = engine.GetCell(moved[0], moved[1])
+			if !isAliveBool(expected.Cells[moved]) || expected.Cells[moved]&^0xFF != 0xFF000000 {
+				t.Fatalf("%s: expected the glider to have moved by (10, 10)", kind)
+			}
+		}
+		checkSnapshot(t, kind, engine.Snapshot(), expected)
+		engine.Close()
+	}
+}
+
+func TestEngine_MatchingEngines(t *testing.T) {
+	for _, rule := range []string{CONWAY_RULE, "B36/S23", "B2/S345/C4"} {
+		var expected []Snapshot
+		for _, kind := range engineKinds {
+			engine, err := NewEngine(kind, 200, 200, rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			engine.SetColorRule(MajorityColors{})
+			random := rand.New(rand.NewSource(5))
+			for y := int64(90); y < 110; y++ {
+				for x := int64(90); x < 110; x++ {
+					if random.Intn(3) == 0 {
+						engine.SetCell(y, x, FULL)
+					}
+				}
+			}
+			for i := 0; i < 60; i++ {
+				engine.Tick()
+				if expected == nil || len(expected) <= i {
+					expected = append(expected, engine.Snapshot())
+				} else {
+					checkSnapshot(t, rule+" on "+kind, engine.Snapshot(), expected[i])
+				}
+			}
+			engine.Close()
+		}
+	}
+}
+
+func TestEngine_ProtoBytes(t *testing.T) {
+	for _, kind := range engineKinds {
+		engine := newTestEngine(t, kind, CONWAY_RULE)
+		engine.PlacePattern(glider, 50, 50, 0xFF000000)
+		engine.Tick()
+		full, err := engine.ToFullProtoBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		min, err := engine.ToMinProtoBytes(true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, marshalled := range [][]byte{full, min} {
+			msg := message.Message{}
+			worldMsg := message.WorldData{}
+			if err := proto.Unmarshal(marshalled, &msg); err != nil || msg.Type != message.MessageType_WORLD_DATA {
+				t.Fatalf("%s: expected world data, got %v (%v)", kind, msg.Type, err)
+			}
+			if err := proto.Unmarshal(msg.Content, &worldMsg); err != nil {
+				t.Fatal(err)
+			}
+			alive := 0
+			for _, cell := range worldMsg.Data {
+				if cell&ALIVE_BIT != 0 {
+					alive++
+				}
+			}
+			if worldMsg.Tick != 1 || alive != len(engine.Snapshot().Cells) {
+				t.Fatalf("%s: expected %d cells at tick 1, got %d at tick %d", kind, len(engine.Snapshot().Cells), alive,
+					worldMsg.Tick)
+			}
+			if i == 0 && worldMsg.Rule != CONWAY_RULE {
+				t.Fatalf("%s: expected the full message to have the rule, got %s", kind, worldMsg.Rule)
+			}
+		}
+		engine.Close()
+	}
+}
+
+func TestNewEngine(t *testing.T) {
+	if _, err := NewEngine(SPARSE_ENGINE, 10, 10, "R5,C0,M1,S34..58,B34..45,NM"); err == nil {
+		t.Error("expected an error running a Larger than Life rule on a sparse engine")
+	}
+	if _, err := NewEngine("quantum", 10, 10, CONWAY_RULE); err == nil {
+		t.Error("expected an error creating an unknown engine")
+	}
+	engine, err := NewEngine(DENSE_ENGINE, 10, 10, CONWAY_RULE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if engine.(*World).packed != nil {
+		t.Error("expected the dense engine not to be packed")
+	}
+	if engine.PlacePattern(glider, -1, 5, FULL) || engine.PlacePattern(glider, 8, 8, FULL) {
+		t.Error("expected patterns outside of a bounded engine not to fit")
+	}
+}
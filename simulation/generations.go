@@ -0,0 +1,37 @@
+package simulation
+
+//The lower byte of a refractory cell counts down by 2 for every state, the same way an alive cell ages with Decay, so
+//state 1 (alive) is ALIVE_NEW, state 2 is 0xFD, and so on. Refractory cells are stored without the ALIVE_BIT, so their
+//neighbors see them as dead, and the most states that fit in the lower byte is 128
+const MAX_STATES = 128
+
+//CellState returns the Generations state of the cell: 0 for dead, 1 for alive, and 2 or more for refractory cells
+func CellState(cell uint32) uint32 {
+	if cell == DEAD {
+		return 0
+	}
+	if isAliveBool(cell) {
+		return 1
+	}
+	lowerByte := (cell | ALIVE_BIT) & ALIVE_NEW
+	return (ALIVE_NEW-lowerByte)/2 + 1
+}
+
+//refractoryCell keeps the color of the cell, but moves it into the given refractory state
+func refractoryCell(cell uint32, state uint32) uint32 {
+	return cell&^ALIVE_NEW | (ALIVE_NEW-2*(state-1))&^ALIVE_BIT
+}
+
+//nextRefractoryState advances a refractory (or alive, but not surviving) cell to its next state, or kills it once it
+//has passed through all the states of the rule
+func (rules *cellRules) nextRefractoryState(cell uint32) uint32 {
+	state := CellState(cell)
+	if state+1 >= rules.states {
+		return DEAD
+	}
+	return refractoryCell(cell, state+1)
+}
+
+func (world *World) GetStates() uint32 {
+	return world.states
+}
@@ -0,0 +1,69 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestCellState(t *testing.T) {
+	cell := uint32(0x12_34_56_00) | ALIVE_NEW
+	if CellState(cell) != 1 || CellState(DEAD) != 0 {
+		t.Fail()
+	}
+	for state := uint32(2); state < MAX_STATES; state++ {
+		refractory := refractoryCell(cell, state)
+		if isAliveBool(refractory) || refractory == DEAD || CellState(refractory) != state {
+			t.Errorf("State %d was stored as %32b", state, refractory)
+		}
+		if refractory&^ALIVE_NEW != 0x12_34_56_00 {
+			t.Errorf("Lost the color of the cell in state %d", state)
+		}
+	}
+}
+
+func TestWorld_TickGenerations(t *testing.T) {
+	//Brian's Brain: a domino of 2 alive cells, which can't survive and become refractory, while the 4 cells above and
+	//below them have 2 alive neighbors each and are born. The cells at either end of the domino only have 1
+	world, err := NewWorldWithRule(10, 10, "B2/S/C3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if world.GetStates() != 3 {
+		t.Fail()
+	}
+	world.MarkAlive(4, 4)
+	world.MarkAlive(4, 5)
+	world.Tick()
+
+	//both cells are now refractory, and the 4 cells above and below them are born
+	for _, x := range []uint32{4, 5} {
+		if CellState((*world.data)[4][x]) != 2 {
+			t.Errorf("Expected (4, %d) to be refractory, got state %d", x, CellState((*world.data)[4][x]))
+		}
+		if CellState((*world.data)[3][x]) != 1 || CellState((*world.data)[5][x]) != 1 {
+			t.Errorf("Expected cells to be born above and below (4, %d)", x)
+		}
+	}
+
+	world.Tick()
+	//the refractory cells die, and the born cells become refractory
+	for _, x := range []uint32{4, 5} {
+		if CellState((*world.data)[4][x]) != 0 {
+			t.Errorf("Expected (4, %d) to be dead, got state %d", x, CellState((*world.data)[4][x]))
+		}
+		if CellState((*world.data)[3][x]) != 2 || CellState((*world.data)[5][x]) != 2 {
+			t.Errorf("Expected cells above and below (4, %d) to be refractory", x)
+		}
+	}
+
+	//refractory cells are sent as alive, so clients can render them
+	flattened := world.GetFlattenedData()
+	refractory := 0
+	for _, cell := range flattened {
+		if isAliveBool(cell) && cell&ALIVE_NEW != ALIVE_NEW {
+			refractory++
+		}
+	}
+	if refractory != 4 {
+		t.Errorf("Expected 4 refractory cells to be sent, got %d", refractory)
+	}
+}
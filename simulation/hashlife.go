@@ -0,0 +1,481 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+)
+
+//hashNode is a square quadtree node of 2^level by 2^level cells. Nodes are immutable and canonical: two nodes with the
+//same contents are always the same pointer, which lets the results of evolving them be shared
+type hashNode struct {
+	nw, ne, sw, se *hashNode
+	level          uint
+	population     uint64
+}
+
+type hashResultKey struct {
+	node *hashNode
+	step uint
+}
+
+//past this many cached results, the caches are cleared (keeping only the nodes of the current pattern)
+const HASHLIFE_MAX_CACHE = 1 << 22
+
+//the largest level of the root, so that its size and the coordinates inside it fit in an int64
+const HASHLIFE_MAX_LEVEL = 62
+
+//the largest step that Step accepts, since a step of 2^n generations needs a root of level n+3
+const HASHLIFE_MAX_STEP = HASHLIFE_MAX_LEVEL - 3
+
+//Hashlife is an alternative to World for advancing patterns of 2-state Life-like rules very far into the future. The
+//plane is unbounded, and the pattern is stored as a quadtree whose root has its top-left corner at (originY, originX)
+type Hashlife struct {
+	rule Rule
+	mask byte
+
+	nodes   map[[4]*hashNode]*hashNode
+	results map[hashResultKey]*hashNode
+	leaves  [2]*hashNode
+	//empty nodes of each level
+	empty []*hashNode
+
+	root       *hashNode
+	originY    int64
+	originX    int64
+	generation uint64
+}
+
+//NewHashlife creates an empty Hashlife universe for the rule, which must be a 2-state rule on the Moore or von
+//Neumann neighborhood, without B0
+func NewHashlife(rulestring string) (*Hashlife, error) {
+	rule, err := ParseRule(rulestring)
+	if err != nil {
+		return nil, err
+	}
+	if rule.States != 2 {
+		return nil, errors.New("hashlife doesn't support Generations rules")
+	}
+	if rule.Neighborhood == HEXAGONAL {
+		return nil, errors.New("hashlife doesn't support the hexagonal neighborhood")
+	}
+	if rule.Birth[0] {
+		return nil, errors.New("hashlife doesn't support rules with B0")
+	}
+
+	h := &Hashlife{
+		rule: rule,
+		mask: rule.Neighborhood.NeighborhoodMasks()[0],
+	}
+	h.clearCaches()
+	h.root = h.emptyNode(3)
+	h.originY = -4
+	h.originX = -4
+	return h, nil
+}
+
+func (h *Hashlife) clearCaches() {
+	h.nodes = make(map[[4]*hashNode]*hashNode)
+	h.results = make(map[hashResultKey]*hashNode)
+	h.leaves = [2]*hashNode{{level: 0, population: 0}, {level: 0, population: 1}}
+	h.empty = []*hashNode{h.leaves[0]}
+}
+
+func (h *Hashlife) join(nw, ne, sw, se *hashNode) *hashNode {
+	key := [4]*hashNode{nw, ne, sw, se}
+	if node, ok := h.nodes[key]; ok {
+		return node
+	}
+	node := &hashNode{
+		nw:         nw,
+		ne:         ne,
+		sw:         sw,
+		se:         se,
+		level:      nw.level + 1,
+		population: nw.population + ne.population + sw.population + se.population,
+	}
+	h.nodes[key] = node
+	return node
+}
+
+func (h *Hashlife) emptyNode(level uint) *hashNode {
+	for uint(len(h.empty)) <= level {
+		e := h.empty[len(h.empty)-1]
+		h.empty = append(h.empty, h.join(e, e, e, e))
+	}
+	return h.empty[level]
+}
+
+//expand doubles the size of the root, keeping the pattern centered. It returns false if the root is already as large
+//as it can be
+func (h *Hashlife) expand() bool {
+	if h.root.level >= HASHLIFE_MAX_LEVEL {
+		return false
+	}
+	e := h.emptyNode(h.root.level - 1)
+	r := h.root
+	h.root = h.join(
+		h.join(e, e, e, r.nw),
+		h.join(e, e, r.ne, e),
+		h.join(e, r.sw, e, e),
+		h.join(r.se, e, e, e),
+	)
+	half := int64(1) << (r.level - 1)
+	h.originY -= half
+	h.originX -= half
+	return true
+}
+
+func (h *Hashlife) size() int64 {
+	return int64(1) << h.root.level
+}
+
+//contains compares the offsets from the origin as unsigned, since they can be too large for an int64 when the
+//coordinates are far from the root
+func (h *Hashlife) contains(y, x int64) bool {
+	size := uint64(h.size())
+	return y >= h.originY && uint64(y)-uint64(h.originY) < size && x >= h.originX && uint64(x)-uint64(h.originX) < size
+}
+
+//SetCell returns false if the cell is too far away to fit in the largest root
+func (h *Hashlife) SetCell(y, x int64, alive bool) bool {
+	for !h.contains(y, x) {
+		if !h.expand() {
+			return false
+		}
+	}
+	h.root = h.setCell(h.root, y-h.originY, x-h.originX, alive)
+	return true
+}
+
+func (h *Hashlife) setCell(node *hashNode, y, x int64, alive bool) *hashNode {
+	if node.level == 0 {
+		if alive {
+			return h.leaves[1]
+		}
+		return h.leaves[0]
+	}
+	half := int64(1) << (node.level - 1)
+	switch {
+	case y < half && x < half:
+		return h.join(h.setCell(node.nw, y, x, alive), node.ne, node.sw, node.se)
+	case y < half:
+		return h.join(node.nw, h.setCell(node.ne, y, x-half, alive), node.sw, node.se)
+	case x < half:
+		return h.join(node.nw, node.ne, h.setCell(node.sw, y-half, x, alive), node.se)
+	default:
+		return h.join(node.nw, node.ne, node.sw, h.setCell(node.se, y-half, x-half, alive))
+	}
+}
+
+func (h *Hashlife) GetCell(y, x int64) bool {
+	if !h.contains(y, x) {
+		return false
+	}
+	node := h.root
+	y -= h.originY
+	x -= h.originX
+	for node.level > 0 {
+		if node.population == 0 {
+			return false
+		}
+		half := int64(1) << (node.level - 1)
+		switch {
+		case y < half && x < half:
+			node = node.nw
+		case y < half:
+			node, x = node.ne, x-half
+		case x < half:
+			node, y = node.sw, y-half
+		default:
+			node, y, x = node.se, y-half, x-half
+		}
+	}
+	return node.population == 1
+}
+
+//LoadRLE adds the alive cells of the RLE to the universe, with its top-left corner at (y, x)
+func (h *Hashlife) LoadRLE(rle RLE, y, x int64) {
+	for yy := uint32(0); yy < rle.height; yy++ {
+		for xx := uint32(0); xx < rle.width; xx++ {
+			if rle.data[yy][xx] {
+				h.SetCell(y+int64(yy), x+int64(xx), true)
+			}
+		}
+	}
+}
+
+func (h *Hashlife) GetGeneration() uint64 {
+	return h.generation
+}
+
+func (h *Hashlife) GetPopulation() uint64 {
+	return h.root.population
+}
+
+//Step advances the universe by 2^log2Generations generations
+func (h *Hashlife) Step(log2Generations uint) error {
+	if log2Generations > HASHLIFE_MAX_STEP {
+		return fmt.Errorf("can't step more than 2^%d generations at once", HASHLIFE_MAX_STEP)
+	}
+	if h.generation+1<<log2Generations < h.generation {
+		return errors.New("generation count would overflow")
+	}
+
+	//the pattern can grow by at most 2^step cells in every direction, so it has to fit in the center square a quarter
+	//of the width of the root, and the root has to be large enough that the center half (which is the result) still
+	//contains the pattern after that growth
+	for h.root.level < log2Generations+3 || !h.centered() {
+		if !h.expand() {
+			return errors.New("the pattern has grown too large to step")
+		}
+	}
+	level := h.root.level
+	h.root = h.evolve(h.root, log2Generations)
+	quarter := int64(1) << (level - 2)
+	h.originY += quarter
+	h.originX += quarter
+	h.generation += 1 << log2Generations
+
+	if len(h.results) > HASHLIFE_MAX_CACHE {
+		h.collectGarbage()
+	}
+	return nil
+}
+
+//Advance moves the universe forward by any number of generations, in power of two steps
+func (h *Hashlife) Advance(generations uint64) error {
+	for step := uint(0); generations > 0; step++ {
+		if generations&1 == 1 {
+			err := h.Step(step)
+			if err != nil {
+				return err
+			}
+		}
+		generations >>= 1
+	}
+	return nil
+}
+
+//centered is true if every alive cell is in the center square of the root, a quarter of its width
+func (h *Hashlife) centered() bool {
+	r := h.root
+	if r.level < 3 {
+		return r.population == 0
+	}
+	return r.nw.population == r.nw.se.se.population && r.ne.population == r.ne.sw.sw.population &&
+		r.sw.population == r.sw.ne.ne.population && r.se.population == r.se.nw.nw.population
+}
+
+func (h *Hashlife) center(node *hashNode) *hashNode {
+	return h.join(node.nw.se, node.ne.sw, node.sw.ne, node.se.nw)
+}
+
+//evolve returns the center half of the node (one level down), 2^step generations into the future. The step can be at
+//most node.level-2, which is how far the edges of the node can affect its center
+func (h *Hashlife) evolve(node *hashNode, step uint) *hashNode {
+	if node.population == 0 {
+		return h.emptyNode(node.level - 1)
+	}
+	key := hashResultKey{node: node, step: step}
+	if result, ok := h.results[key]; ok {
+		return result
+	}
+
+	var result *hashNode
+	if node.level == 2 {
+		result = h.evolveBase(node)
+	} else {
+		//the 9 overlapping sub-squares of half the size of the node
+		n00 := node.nw
+		n01 := h.join(node.nw.ne, node.ne.nw, node.nw.se, node.ne.sw)
+		n02 := node.ne
+		n10 := h.join(node.nw.sw, node.nw.se, node.sw.nw, node.sw.ne)
+		n11 := h.center(node)
+		n12 := h.join(node.ne.sw, node.ne.se, node.se.nw, node.se.ne)
+		n20 := node.sw
+		n21 := h.join(node.sw.ne, node.se.nw, node.sw.se, node.se.sw)
+		n22 := node.se
+
+		var first func(*hashNode) *hashNode
+		var secondStep uint
+		if step == node.level-2 {
+			//full speed: each of the two halves advances 2^(step-1) generations
+			first = func(n *hashNode) *hashNode { return h.evolve(n, step-1) }
+			secondStep = step - 1
+		} else {
+			//slower: the first half doesn't advance at all
+			first = h.center
+			secondStep = step
+		}
+		r00, r01, r02 := first(n00), first(n01), first(n02)
+		r10, r11, r12 := first(n10), first(n11), first(n12)
+		r20, r21, r22 := first(n20), first(n21), first(n22)
+
+		result = h.join(
+			h.evolve(h.join(r00, r01, r10, r11), secondStep),
+			h.evolve(h.join(r01, r02, r11, r12), secondStep),
+			h.evolve(h.join(r10, r11, r20, r21), secondStep),
+			h.evolve(h.join(r11, r12, r21, r22), secondStep),
+		)
+	}
+	h.results[key] = result
+	return result
+}
+
+//evolveBase advances the center 2x2 of a 4x4 node by one generation
+func (h *Hashlife) evolveBase(node *hashNode) *hashNode {
+	var cells [4][4]bool
+	quadrants := [4]*hashNode{node.nw, node.ne, node.sw, node.se}
+	for i, quadrant := range quadrants {
+		y := (i / 2) * 2
+		x := (i % 2) * 2
+		cells[y][x] = quadrant.nw.population == 1
+		cells[y][x+1] = quadrant.ne.population == 1
+		cells[y+1][x] = quadrant.sw.population == 1
+		cells[y+1][x+1] = quadrant.se.population == 1
+	}
+
+	var next [4]*hashNode
+	for i := 0; i < 4; i++ {
+		y := 1 + i/2
+		x := 1 + i%2
+		neighborhood := byte(0)
+		for dir := N; dir <= NW; dir++ {
+			if cells[y+YOffsets[dir]][x+XOffsets[dir]] {
+				neighborhood |= DirectionMasks[dir]
+			}
+		}
+		neighborhood &= h.mask
+		alive := (cells[y][x] && h.rule.Survive[neighborhood]) || (!cells[y][x] && h.rule.Birth[neighborhood])
+		if alive {
+			next[i] = h.leaves[1]
+		} else {
+			next[i] = h.leaves[0]
+		}
+	}
+	return h.join(next[0], next[1], next[2], next[3])
+}
+
+//collectGarbage drops every cached node and result that isn't part of the current pattern
+func (h *Hashlife) collectGarbage() {
+	root := h.root
+	h.clearCaches()
+	h.root = h.rebuild(root, make(map[*hashNode]*hashNode))
+}
+
+func (h *Hashlife) rebuild(node *hashNode, rebuilt map[*hashNode]*hashNode) *hashNode {
+	if node.level == 0 {
+		return h.leaves[node.population]
+	}
+	if node.population == 0 {
+		return h.emptyNode(node.level)
+	}
+	if newNode, ok := rebuilt[node]; ok {
+		return newNode
+	}
+	newNode := h.join(h.rebuild(node.nw, rebuilt), h.rebuild(node.ne, rebuilt), h.rebuild(node.sw, rebuilt),
+		h.rebuild(node.se, rebuilt))
+	rebuilt[node] = newNode
+	return newNode
+}
+
+//GetBounds returns the smallest rectangle containing every alive cell, or false if there are none
+func (h *Hashlife) GetBounds() (minY, minX, maxY, maxX int64, ok bool) {
+	if h.root.population == 0 {
+		return 0, 0, 0, 0, false
+	}
+	top := func(n *hashNode) [2]*hashNode { return [2]*hashNode{n.nw, n.ne} }
+	bottom := func(n *hashNode) [2]*hashNode { return [2]*hashNode{n.sw, n.se} }
+	left := func(n *hashNode) [2]*hashNode { return [2]*hashNode{n.nw, n.sw} }
+	right := func(n *hashNode) [2]*hashNode { return [2]*hashNode{n.ne, n.se} }
+
+	last := h.size() - 1
+	minY = h.originY + edgeOffset(h.root, top, bottom, make(map[*hashNode]int64))
+	maxY = h.originY + last - edgeOffset(h.root, bottom, top, make(map[*hashNode]int64))
+	minX = h.originX + edgeOffset(h.root, left, right, make(map[*hashNode]int64))
+	maxX = h.originX + last - edgeOffset(h.root, right, left, make(map[*hashNode]int64))
+	return minY, minX, maxY, maxX, true
+}
+
+//edgeOffset returns how far the closest alive cell is from one edge of the (non-empty) node. near returns the two
+//children along that edge, and far the other two
+func edgeOffset(node *hashNode, near, far func(*hashNode) [2]*hashNode, memo map[*hashNode]int64) int64 {
+	if node.level == 0 {
+		return 0
+	}
+	if offset, ok := memo[node]; ok {
+		return offset
+	}
+	offset := int64(-1)
+	for _, child := range near(node) {
+		if child.population > 0 {
+			childOffset := edgeOffset(child, near, far, memo)
+			if offset < 0 || childOffset < offset {
+				offset = childOffset
+			}
+		}
+	}
+	if offset < 0 {
+		for _, child := range far(node) {
+			if child.population > 0 {
+				childOffset := edgeOffset(child, near, far, memo)
+				if offset < 0 || childOffset < offset {
+					offset = childOffset
+				}
+			}
+		}
+		offset += int64(1) << (node.level - 1)
+	}
+	memo[node] = offset
+	return offset
+}
+
+//forEachAlive calls f with the coordinates of every alive cell of the node (with its top-left corner at (y, x)) that
+//lies in the rectangle from (minY, minX) to (maxY, maxX) inclusive
+func (h *Hashlife) forEachAlive(node *hashNode, y, x, minY, minX, maxY, maxX int64, f func(y, x int64)) {
+	size := int64(1) << node.level
+	if node.population == 0 || y > maxY || x > maxX || y+size-1 < minY || x+size-1 < minX {
+		return
+	}
+	if node.level == 0 {
+		f(y, x)
+		return
+	}
+	half := size / 2
+	h.forEachAlive(node.nw, y, x, minY, minX, maxY, maxX, f)
+	h.forEachAlive(node.ne, y, x+half, minY, minX, maxY, maxX, f)
+	h.forEachAlive(node.sw, y+half, x, minY, minX, maxY, maxX, f)
+	h.forEachAlive(node.se, y+half, x+half, minY, minX, maxY, maxX, f)
+}
+
+//RegionToRLE copies the rectangle with its top-left corner at (y, x) into an RLE
+func (h *Hashlife) RegionToRLE(name string, y, x int64, height, width uint32) RLE {
+	rle := RLE{
+		name:   name,
+		width:  width,
+		height: height,
+		data:   make([][]bool, height),
+	}
+	for yy := range rle.data {
+		rle.data[yy] = make([]bool, width)
+	}
+	h.forEachAlive(h.root, h.originY, h.originX, y, x, y+int64(height)-1, x+int64(width)-1, func(cy, cx int64) {
+		rle.data[cy-y][cx-x] = true
+	})
+	return rle
+}
+
+//ToRLE copies the whole pattern into an RLE, cropped to its bounds
+func (h *Hashlife) ToRLE(name string) RLE {
+	minY, minX, maxY, maxX, ok := h.GetBounds()
+	if !ok {
+		return RLE{name: name}
+	}
+	return h.RegionToRLE(name, minY, minX, uint32(maxY-minY+1), uint32(maxX-minX+1))
+}
+
+//ExportToWorld places the rectangle of the universe with its top-left corner at (srcY, srcX) into the world, with
+//its top-left corner at (y, x)
+func (h *Hashlife) ExportToWorld(world *World, srcY, srcX int64, height, width uint32, y, x, color uint32) bool {
+	return world.PlaceRLEAtCoords(h.RegionToRLE("", srcY, srcX, height, width), y, x, color)
+}
@@ -0,0 +1,129 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHashlife_MatchesWorld(t *testing.T) {
+	gun, err := LoadRLE("../data/glider.rle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	world := NewConwayWorld(300, 300)
+	world.PlaceRLEAtCoords(gun, 10, 10, FULL)
+	h, err := NewHashlife(CONWAY_RULE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.LoadRLE(gun, 10, 10)
+
+	for i := 0; i < 200; i++ {
+		world.Tick()
+	}
+	err = h.Advance(200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.GetGeneration() != 200 {
+		t.Errorf("Expected generation 200, got %d", h.GetGeneration())
+	}
+
+	expected := aliveCells(&world)
+	if h.GetPopulation() != uint64(len(expected)) {
+		t.Fatalf("Expected a population of %d, got %d", len(expected), h.GetPopulation())
+	}
+	for cell := range expected {
+		if !h.GetCell(int64(cell[0]), int64(cell[1])) {
+			t.Errorf("Expected %v to be alive", cell)
+		}
+	}
+
+	//exporting the universe back into an empty world gives the same world
+	exported := NewConwayWorld(300, 300)
+	minY, minX, maxY, maxX, _ := h.GetBounds()
+	if !h.ExportToWorld(&exported, minY, minX, uint32(maxY-minY+1), uint32(maxX-minX+1), uint32(minY), uint32(minX), FULL) {
+		t.Fatal("Failed to export")
+	}
+	actual := aliveCells(&exported)
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d cells to be exported, got %d", len(expected), len(actual))
+	}
+	for cell := range expected {
+		if !actual[cell] {
+			t.Errorf("Expected %v to be exported", cell)
+		}
+	}
+}
+
+func TestHashlife_StepSizes(t *testing.T) {
+	gun, _ := LoadRLE("../data/glider.rle")
+	slow, _ := NewHashlife(CONWAY_RULE)
+	fast, _ := NewHashlife(CONWAY_RULE)
+	slow.LoadRLE(gun, 0, 0)
+	fast.LoadRLE(gun, 0, 0)
+	for i := 0; i < 1000; i++ {
+		slow.Step(0)
+	}
+	fast.Advance(1000)
+
+	slowRLE := slow.ToRLE("slow")
+	fastRLE := fast.ToRLE("fast")
+	if slowRLE.height != fastRLE.height || slowRLE.width != fastRLE.width || slow.GetPopulation() != fast.GetPopulation() {
+		t.Fatalf("Expected %dx%d with population %d, got %dx%d with population %d", slowRLE.height, slowRLE.width,
+			slow.GetPopulation(), fastRLE.height, fastRLE.width, fast.GetPopulation())
+	}
+	for y := range slowRLE.data {
+		for x := range slowRLE.data[y] {
+			if slowRLE.data[y][x] != fastRLE.data[y][x] {
+				t.Fatalf("Mismatch at (%d, %d)", y, x)
+			}
+		}
+	}
+}
+
+func TestHashlife_GliderFarFuture(t *testing.T) {
+	h, _ := NewHashlife(CONWAY_RULE)
+	h.LoadRLE(glider, 0, 0)
+	err := h.Step(40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//a glider moves one cell down and to the right every 4 generations
+	offset := int64(1) << 38
+	minY, minX, maxY, maxX, ok := h.GetBounds()
+	if !ok || minY != offset || minX != offset || maxY != offset+2 || maxX != offset+2 || h.GetPopulation() != 5 {
+		t.Errorf("Unexpected glider bounds (%d, %d) to (%d, %d)", minY, minX, maxY, maxX)
+	}
+}
+
+func TestNewHashlife(t *testing.T) {
+	for _, rulestring := range []string{"B2/S/C3", "B2/S34H", "B03/S23", "R5,C0,M1,S34..58,B34..45,NM"} {
+		if _, err := NewHashlife(rulestring); err == nil {
+			t.Errorf("Expected an error creating a hashlife universe for %s", rulestring)
+		}
+	}
+}
+
+func TestHashlife_MaxStep(t *testing.T) {
+	h, _ := NewHashlife(CONWAY_RULE)
+	h.LoadRLE(glider, 0, 0)
+	err := h.Step(HASHLIFE_MAX_STEP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Step(HASHLIFE_MAX_STEP + 1); err == nil {
+		t.Error("Expected an error stepping past the largest step")
+	}
+
+	if !h.SetCell(-3, 7, true) || !h.GetCell(-3, 7) {
+		t.Error("Expected (-3, 7) to be set after the largest step")
+	}
+	if h.GetPopulation() != 6 {
+		t.Errorf("Expected a population of 6, got %d", h.GetPopulation())
+	}
+	//cells past the largest root can't be set
+	if h.SetCell(math.MaxInt64, 0, true) || h.GetCell(math.MaxInt64, 0) {
+		t.Error("Expected a cell past the largest root not to be set")
+	}
+}
@@ -0,0 +1,295 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+)
+
+//History keeps the past generations of a world, as a full copy (keyframe) of the world every keyframeInterval
+//generations, and only the cells that changed for the generations in between. Once there are more than
+//maxGenerations, the oldest keyframe and the generations that depend on it are dropped
+type History struct {
+	maxGenerations   int
+	keyframeInterval int
+	generations      int
+	segments         []*historySegment
+	//the segment and entry that the world's data matches, and a full copy of that data
+	segment int
+	entry   int
+	current []uint32
+	//set when the world's data was edited since it was last recorded
+	stale bool
+}
+
+//historySegment is a keyframe, and the generations that follow it. The first entry is the keyframe itself
+type historySegment struct {
+	keyframe []uint32
+	entries  []historyEntry
+}
+
+//historyEntry is a generation of the world, or an edit of the world, which has the same tick as the generation it
+//edits
+type historyEntry struct {
+	tick    uint64
+	changes []cellChange
+}
+
+type cellChange struct {
+	index uint32
+	cell  uint32
+}
+
+//EnableHistory starts recording every generation (and edit) of the world, keeping up to maxGenerations of them with a
+//full copy of the world every keyframeInterval generations
+func (world *World) EnableHistory(maxGenerations, keyframeInterval uint32) error {
+	if maxGenerations == 0 || keyframeInterval == 0 {
+		return errors.New("the history needs at least 1 generation and a keyframe interval of at least 1")
+	}
+	world.history = &History{
+		maxGenerations:   int(maxGenerations),
+		keyframeInterval: int(keyframeInterval),
+		current:          make([]uint32, world.height*world.width),
+	}
+	world.history.newSegment(world.tick, world.data)
+	return nil
+}
+
+func (world *World) DisableHistory() {
+	world.history = nil
+}
+
+//GetHistoryRange returns the oldest and newest ticks that the world can go back (or forward) to
+func (world *World) GetHistoryRange() (oldest, newest uint64, ok bool) {
+	if world.history == nil {
+		return 0, 0, false
+	}
+	segments := world.history.segments
+	last := segments[len(segments)-1]
+	return segments[0].entries[0].tick, last.entries[len(last.entries)-1].tick, true
+}
+
+//newSegment starts a segment with a keyframe of the data
+func (history *History) newSegment(tick uint64, data *DataGrid) {
+	width := len(history.current) / len(*data)
+	for y, row := range *data {
+		copy(history.current[y*width:], row)
+	}
+	keyframe := make([]uint32, len(history.current))
+	copy(keyframe, history.current)
+	history.segments = append(history.segments, &historySegment{
+		keyframe: keyframe,
+		entries:  []historyEntry{{tick: tick}},
+	})
+	history.segment = len(history.segments) - 1
+	history.entry = 0
+	history.generations++
+}
+
+//recordHistory records the world's current data as the generation of its current tick. Only the regions that changed
+//last tick are compared, as the rest of the world still matches the last generation recorded
+func (world *World) recordHistory() {
+	history := world.history
+	if history == nil {
+		return
+	}
+	//the generations after the current one belong to a timeline the world has moved on from
+	for _, dropped := range history.segments[history.segment+1:] {
+		history.generations -= len(dropped.entries)
+	}
+	history.segments = history.segments[:history.segment+1]
+	segment := history.segments[history.segment]
+	history.generations -= len(segment.entries) - history.entry - 1
+	segment.entries = segment.entries[:history.entry+1]
+	history.stale = false
+
+	if len(segment.entries) >= history.keyframeInterval {
+		history.newSegment(world.tick, world.data)
+	} else {
+		changes := make([]cellChange, 0)
+		for _, r := range world.GetChangedRegions() {
+			for y := r[0]; y < r[2]; y++ {
+				for x := r[1]; x < r[3]; x++ {
+					index := y*world.width + x
+					if cell := (*world.data)[y][x]; cell != history.current[index] {
+						changes = append(changes, cellChange{index: index, cell: cell})
+						history.current[index] = cell
+					}
+				}
+			}
+		}
+		segment.entries = append(segment.entries, historyEntry{tick: world.tick, changes: changes})
+		history.entry++
+		history.generations++
+	}
+
+	for history.generations > history.maxGenerations && len(history.segments) > 1 {
+		history.generations -= len(history.segments[0].entries)
+		history.segments = history.segments[1:]
+		history.segment--
+	}
+}
+
+//edited must be called whenever the world's data is edited (rather than ticked)
+func (world *World) edited() {
+	world.dataChanged()
+	world.editsTracked()
+}
+
+//editedCell is edited for when only the cell at (y, x) is edited, which the next frame only has to include the region
+//of, rather than the whole world
+func (world *World) editedCell(y, x uint32) {
+	world.cellChanged(y, x)
+	world.editsTracked()
+}
+
+//editsTracked has the stabilization detection, stats and history follow from the edited data
+func (world *World) editsTracked() {
+	world.resetStability()
+	world.recountStats()
+	if world.history != nil {
+		world.history.stale = true
+	}
+}
+
+//restartTracking starts the history and stats over from the current generation, keeping their settings, for when the
+//generations kept so far no longer lead up to it
+func (world *World) restartTracking() {
+	if world.history != nil {
+		//the parameters were accepted when the history was enabled
+		_ = world.EnableHistory(uint32(world.history.maxGenerations), uint32(world.history.keyframeInterval))
+	}
+	if world.stats != nil {
+		colors := world.stats.colors
+		_ = world.EnableStats(uint32(world.stats.maxTicks))
+		world.stats.colors = colors
+	}
+}
+
+//recordEdits records the edits made since the last generation was recorded, so they aren't lost by moving through
+//the history
+func (world *World) recordEdits() {
+	if world.history != nil && world.history.stale {
+		world.recordHistory()
+	}
+}
+
+//findEntry returns the last entry matching the predicate on its tick, searching from the newest generation
+func (history *History) findEntry(matches func(tick uint64) bool) (int, int, bool) {
+	for s := len(history.segments) - 1; s >= 0; s-- {
+		entries := history.segments[s].entries
+		for e := len(entries) - 1; e >= 0; e-- {
+			if matches(entries[e].tick) {
+				return s, e, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+//reconstruct fills data with the generation of the entry, from its keyframe and the changes since then
+func (history *History) reconstruct(segment, entry int, data []uint32) {
+	s := history.segments[segment]
+	copy(data, s.keyframe)
+	for _, e := range s.entries[1 : entry+1] {
+		for _, change := range e.changes {
+			data[change.index] = change.cell
+		}
+	}
+}
+
+//restore moves the world to the generation of the entry. The generations after it are kept until the world ticks or
+//is edited, so the world can go back and forth between them
+func (world *World) restore(segment, entry int) {
+	history := world.history
+	history.reconstruct(segment, entry, history.current)
+	history.segment = segment
+	history.entry = entry
+	for y := uint32(0); y < world.height; y++ {
+		copy((*world.data)[y], history.current[y*world.width:(y+1)*world.width])
+	}
+	world.tick = history.segments[segment].entries[entry].tick
+	world.dataChanged()
+	world.resetStability()
+	world.recountStats()
+}
+
+//StepBack moves the world to the generation before its current one, returning false if it isn't in the history
+func (world *World) StepBack() bool {
+	if world.history == nil {
+		return false
+	}
+	world.recordEdits()
+	tick := world.tick
+	segment, entry, ok := world.history.findEntry(func(t uint64) bool { return t < tick })
+	if ok {
+		world.restore(segment, entry)
+	}
+	return ok
+}
+
+//StepForward moves the world to the generation after its current one, if the world has stepped back from it before,
+//returning false otherwise
+func (world *World) StepForward() bool {
+	if world.history == nil {
+		return false
+	}
+	world.recordEdits()
+	tick := world.tick
+	segment, entry, ok := world.history.findEntry(func(t uint64) bool { return t == tick+1 })
+	if ok {
+		world.restore(segment, entry)
+	}
+	return ok
+}
+
+//JumpToTick moves the world to the generation of the tick, including any edits made during it
+func (world *World) JumpToTick(tick uint64) error {
+	if world.history == nil {
+		return errors.New("the world doesn't have a history")
+	}
+	world.recordEdits()
+	segment, entry, ok := world.history.findEntry(func(t uint64) bool { return t == tick })
+	if !ok {
+		return fmt.Errorf("tick %d isn't in the history", tick)
+	}
+	world.restore(segment, entry)
+	return nil
+}
+
+//Fork creates a new world (without any history) with the same rule, topology and workers, starting from the
+//generation of the tick. The fork starts its own worker pool the first time it ticks, so it has to be closed once it's
+//no longer used
+func (world *World) Fork(tick uint64) (World, error) {
+	if world.history == nil {
+		return World{}, errors.New("the world doesn't have a history")
+	}
+	world.recordEdits()
+	segment, entry, ok := world.history.findEntry(func(t uint64) bool { return t == tick })
+	if !ok {
+		return World{}, fmt.Errorf("tick %d isn't in the history", tick)
+	}
+
+	fork := world.emptyCopy()
+	data := make([]uint32, world.height*world.width)
+	world.history.reconstruct(segment, entry, data)
+	for y := uint32(0); y < world.height; y++ {
+		copy((*fork.data)[y], data[y*world.width:(y+1)*world.width])
+	}
+	fork.tick = tick
+	return fork, nil
+}
+
+//emptyCopy creates an empty world with the same dimensions and configuration as this one
+func (world *World) emptyCopy() World {
+	empty := newWorld(world.height, world.width, nil, nil, world.rule, world.states)
+	empty.cellRules = world.cellRules
+	empty.topology = world.topology
+	empty.ltl = world.ltl
+	empty.margolus = world.margolus
+	empty.elementary = world.elementary
+	if world.packed != nil {
+		empty.packed = newPackedGrid(world.height, world.width, world.cellRules)
+	}
+	empty.SetWorkers(world.workers, world.partitioning)
+	return empty
+}
@@ -0,0 +1,117 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func copyData(world *World) [][]uint32 {
+	data := make([][]uint32, world.height)
+	for y := range data {
+		data[y] = append([]uint32{}, (*world.data)[y]...)
+	}
+	return data
+}
+
+func checkData(t *testing.T, world *World, expected [][]uint32) {
+	t.Helper()
+	for y := uint32(0); y < world.height; y++ {
+		for x := uint32(0); x < world.width; x++ {
+			if (*world.data)[y][x] != expected[y][x] {
+				t.Fatalf("tick %d: cell (%d, %d) is %08x, expected %08x", world.tick, y, x, (*world.data)[y][x],
+					expected[y][x])
+			}
+		}
+	}
+}
+
+func TestWorld_History(t *testing.T) {
+	for _, colors := range []ColorRule{RGBBlend{}, MajorityColors{}} {
+		world := NewConwayWorld(50, 60)
+		world.SetColorRule(colors)
+		err := world.EnableHistory(100, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		randomSoup(&world, 4, []uint32{FULL})
+		generations := [][][]uint32{copyData(&world)}
+		for i := 0; i < 150; i++ {
+			if i == 120 {
+				//edits are recorded with the generation they edit
+				world.PlaceRLEAtCoords(glider, 10, 10, 0xFF000000)
+				generations[i] = copyData(&world)
+			}
+			world.Tick()
+			generations = append(generations, copyData(&world))
+		}
+
+		oldest, newest, ok := world.GetHistoryRange()
+		if !ok || newest != 150 || oldest < 50 || oldest > 57 {
+			t.Fatalf("expected the history to go from ~51 to 150, got %d to %d", oldest, newest)
+		}
+		if err := world.JumpToTick(oldest - 1); err == nil {
+			t.Fatal("expected an error jumping to a dropped tick")
+		}
+
+		for _, tick := range []uint64{120, oldest, 149, 63, 150, 121} {
+			err := world.JumpToTick(tick)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkData(t, &world, generations[tick])
+		}
+		for world.StepBack() {
+			checkData(t, &world, generations[world.tick])
+		}
+		if world.tick != oldest {
+			t.Fatalf("expected to step back to %d, got %d", oldest, world.tick)
+		}
+		for world.StepForward() {
+			checkData(t, &world, generations[world.tick])
+		}
+		if world.tick != newest {
+			t.Fatalf("expected to step forward to %d, got %d", newest, world.tick)
+		}
+
+		//going back and ticking again replaces the generations after it
+		world.JumpToTick(100)
+		world.MarkAliveColor(30, 30, FULL)
+		world.Tick()
+		if _, newest, _ := world.GetHistoryRange(); newest != 101 {
+			t.Fatalf("expected the newest tick to be 101, got %d", newest)
+		}
+		replaced := copyData(&world)
+		world.StepBack()
+		world.StepForward()
+		checkData(t, &world, replaced)
+	}
+}
+
+func TestWorld_Fork(t *testing.T) {
+	world := NewConwayWorld(40, 40)
+	world.SetTopology(TORUS)
+	world.EnableHistory(50, 10)
+	randomSoup(&world, 5, []uint32{FULL})
+	for i := 0; i < 30; i++ {
+		world.Tick()
+	}
+	expected := copyData(&world)
+
+	fork, err := world.Fork(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fork.GetTick() != 20 || fork.GetTopology() != TORUS || fork.history != nil {
+		t.Fatalf("unexpected fork at tick %d on a %s world", fork.GetTick(), fork.GetTopology())
+	}
+	defer fork.Close()
+	for i := 0; i < 10; i++ {
+		fork.Tick()
+	}
+	checkData(t, &fork, expected)
+	if world.tick != 30 {
+		t.Fatalf("forking shouldn't move the world, but it's at tick %d", world.tick)
+	}
+	if _, err := world.Fork(31); err == nil {
+		t.Fatal("expected an error forking from a tick that hasn't happened")
+	}
+}
@@ -0,0 +1,124 @@
+package simulation
+
+import (
+	"math/bits"
+)
+
+//Hensel letters for each neighbor count, in the conventional order. Counts 0 and 8 only have a single class, and so
+//have no letters
+var HenselLetters = [9]string{
+	"",
+	"ce",
+	"ceaikn",
+	"ceaiknjqry",
+	"ceaiknjqrtwyz",
+	"ceaiknjqry",
+	"ceaikn",
+	"ce",
+	"",
+}
+
+//Representative neighborhoods for the letters of counts 1-4 (in the order of HenselLetters), using the
+//0b_W_SW_S_SE_E_NE_N_NW layout. Counts 5-7 use the complement of the 8-n representative with the same letter
+var henselRepresentatives = [5][]byte{
+	{},
+	{maskNW, maskN},
+	{maskNW | maskNE, maskN | maskE, maskNW | maskN, maskE | maskW, maskNW | maskE, maskNE | maskSW},
+	{
+		maskNW | maskNE | maskSW,
+		maskN | maskE | maskW,
+		maskNW | maskN | maskW,
+		maskNW | maskN | maskNE,
+		maskN | maskE | maskSW,
+		maskNW | maskNE | maskW,
+		maskN | maskNE | maskW,
+		maskN | maskNE | maskSW,
+		maskNW | maskE | maskW,
+		maskNW | maskE | maskSW,
+	},
+	{
+		maskNW | maskNE | maskSE | maskSW,
+		maskN | maskE | maskS | maskW,
+		maskNW | maskN | maskNE | maskW,
+		maskNW | maskNE | maskE | maskW,
+		maskNW | maskN | maskE | maskSW,
+		maskNW | maskN | maskNE | maskSW,
+		maskN | maskE | maskSW | maskW,
+		maskN | maskNE | maskE | maskSW,
+		maskNW | maskN | maskE | maskW,
+		maskNW | maskNE | maskE | maskSW,
+		maskNW | maskE | maskSW | maskW,
+		maskN | maskNE | maskSW | maskW,
+		maskNE | maskE | maskSW | maskW,
+	},
+}
+
+//single neighbor masks, matching DirectionMasks
+const (
+	maskNW byte = 1 << iota
+	maskN
+	maskNE
+	maskE
+	maskSE
+	maskS
+	maskSW
+	maskW
+)
+
+//henselClasses maps every neighborhood byte to the Hensel letter of its isotropic class (0 for counts 0 and 8)
+var henselClasses = generateHenselClasses()
+
+func generateHenselClasses() [256]byte {
+	classes := [256]byte{}
+	letterOfCanonical := make(map[byte]byte)
+	for count := 1; count < 8; count++ {
+		for i, letter := range HenselLetters[count] {
+			var rep byte
+			if count <= 4 {
+				rep = henselRepresentatives[count][i]
+			} else {
+				rep = ^henselRepresentatives[8-count][i]
+			}
+			letterOfCanonical[CanonicalNeighborhood(rep)] = byte(letter)
+		}
+	}
+	for i := 0; i < 256; i++ {
+		classes[i] = letterOfCanonical[CanonicalNeighborhood(byte(i))]
+	}
+	return classes
+}
+
+//CanonicalNeighborhood returns the smallest neighborhood value among the 8 rotations and reflections of the
+//neighborhood, such that two neighborhoods are in the same isotropic class iff their canonical values are equal
+func CanonicalNeighborhood(neighborhood byte) byte {
+	canonical := neighborhood
+	rotated := neighborhood
+	for i := 0; i < 4; i++ {
+		if rotated < canonical {
+			canonical = rotated
+		}
+		reflected := reflectNeighborhood(rotated)
+		if reflected < canonical {
+			canonical = reflected
+		}
+		//each 90 degree rotation moves every neighbor 2 bits clockwise
+		rotated = bits.RotateLeft8(rotated, 2)
+	}
+	return canonical
+}
+
+//mirrors the neighborhood across the N-S axis
+func reflectNeighborhood(neighborhood byte) byte {
+	reflected := byte(0)
+	for i := 0; i < 8; i++ {
+		if neighborhood&(1<<i) > 0 {
+			reflected |= 1 << ((10 - i) % 8)
+		}
+	}
+	return reflected
+}
+
+//HenselClass returns the neighbor count and Hensel letter of the neighborhood. The letter is 0 for counts 0 and 8
+func HenselClass(neighborhood byte) (count int, letter byte) {
+	return NumNeighbors(neighborhood), henselClasses[neighborhood]
+}
@@ -0,0 +1,47 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestHenselClasses(t *testing.T) {
+	for count := 0; count <= 8; count++ {
+		classes := make(map[byte]map[byte]bool)
+		for i := 0; i < 256; i++ {
+			c, letter := HenselClass(byte(i))
+			if c != count {
+				continue
+			}
+			if classes[letter] == nil {
+				classes[letter] = make(map[byte]bool)
+			}
+			classes[letter][CanonicalNeighborhood(byte(i))] = true
+		}
+		expected := len(HenselLetters[count])
+		if expected == 0 {
+			expected = 1
+		}
+		if len(classes) != expected {
+			t.Errorf("Expected %d letters for count %d, got %d", expected, count, len(classes))
+		}
+		for letter, canonicals := range classes {
+			if len(canonicals) != 1 {
+				t.Errorf("Letter %c of count %d spans %d isotropic classes", letter, count, len(canonicals))
+			}
+		}
+	}
+
+	//spot check a few well-known shapes
+	if _, letter := HenselClass(maskN | maskS); letter != 'i' {
+		t.Fail()
+	}
+	if _, letter := HenselClass(maskSE | maskNW); letter != 'n' {
+		t.Fail()
+	}
+	if _, letter := HenselClass(maskSW | maskS | maskSE); letter != 'i' {
+		t.Fail()
+	}
+	if _, letter := HenselClass(^byte(maskS)); letter != 'e' {
+		t.Fail()
+	}
+}
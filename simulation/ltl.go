@@ -0,0 +1,329 @@
+package simulation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const MAX_LTL_RANGE = 500
+
+//LtLRule is a Larger than Life rule, where a cell's neighborhood is every cell within Range of it, and births and
+//survivals happen when the number of alive cells in the neighborhood falls within an interval
+type LtLRule struct {
+	Range        uint32
+	States       uint32
+	Middle       bool
+	SurviveMin   uint32
+	SurviveMax   uint32
+	BirthMin     uint32
+	BirthMax     uint32
+	Neighborhood NeighborhoodType
+}
+
+func IsLtLRule(rulestring string) bool {
+	str := strings.ToLower(strings.TrimSpace(rulestring))
+	return len(str) > 1 && str[0] == 'r' && str[1] >= '0' && str[1] <= '9'
+}
+
+//ParseLtLRule parses rulestrings in the form "R5,C0,M1,S34..58,B34..45,NM" (Bosco's Rule). C is the number of states
+//(0 and 1 mean 2), M is whether the cell itself is counted, and N is the neighborhood; M for Moore or N for von Neumann
+func ParseLtLRule(rulestring string) (LtLRule, error) {
+	rule := LtLRule{States: 2, Neighborhood: MOORE}
+	seen := make(map[byte]bool)
+	for _, field := range strings.Split(strings.ToLower(strings.ReplaceAll(rulestring, " ", "")), ",") {
+		if field == "" {
+			return rule, fmt.Errorf("invalid rulestring %s", rulestring)
+		}
+		if seen[field[0]] {
+			return rule, fmt.Errorf("duplicate field %c in rulestring %s", field[0], rulestring)
+		}
+		seen[field[0]] = true
+
+		var err error
+		value := field[1:]
+		switch field[0] {
+		case 'r':
+			rule.Range, err = parseLtLValue(value)
+			if err == nil && (rule.Range < 1 || rule.Range > MAX_LTL_RANGE) {
+				err = fmt.Errorf("range must be between 1 and %d", MAX_LTL_RANGE)
+			}
+		case 'c':
+			rule.States, err = parseLtLValue(value)
+			if rule.States < 2 {
+				rule.States = 2
+			} else if rule.States > MAX_STATES {
+				err = fmt.Errorf("number of states must be between 2 and %d", MAX_STATES)
+			}
+		case 'm':
+			if value != "0" && value != "1" {
+				err = fmt.Errorf("invalid middle value %s", value)
+			}
+			rule.Middle = value == "1"
+		case 's':
+			rule.SurviveMin, rule.SurviveMax, err = parseLtLInterval(value)
+		case 'b':
+			rule.BirthMin, rule.BirthMax, err = parseLtLInterval(value)
+		case 'n':
+			if value == "m" {
+				rule.Neighborhood = MOORE
+			} else if value == "n" {
+				rule.Neighborhood = VON_NEUMANN
+			} else {
+				err = fmt.Errorf("invalid neighborhood %s", value)
+			}
+		default:
+			err = fmt.Errorf("invalid field %s", field)
+		}
+		if err != nil {
+			return rule, err
+		}
+	}
+	if !seen['r'] || !seen['s'] || !seen['b'] {
+		return rule, fmt.Errorf("rulestring %s needs at least a range, survival and birth", rulestring)
+	}
+	return rule, nil
+}
+
+func parseLtLValue(value string) (uint32, error) {
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %s", value)
+	}
+	return uint32(parsed), nil
+}
+
+//parses "a..b", or a single "a"
+func parseLtLInterval(interval string) (uint32, uint32, error) {
+	split := strings.Split(interval, "..")
+	if len(split) > 2 {
+		return 0, 0, fmt.Errorf("invalid interval %s", interval)
+	}
+	low, err := parseLtLValue(split[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	high := low
+	if len(split) == 2 {
+		high, err = parseLtLValue(split[1])
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if high < low {
+		return 0, 0, fmt.Errorf("invalid interval %s", interval)
+	}
+	return low, high, nil
+}
+
+func (rule LtLRule) String() string {
+	middle := 0
+	if rule.Middle {
+		middle = 1
+	}
+	neighborhood := "M"
+	if rule.Neighborhood == VON_NEUMANN {
+		neighborhood = "N"
+	}
+	states := rule.States
+	if states == 2 {
+		states = 0
+	}
+	return fmt.Sprintf("R%d,C%d,M%d,S%d..%d,B%d..%d,N%s", rule.Range, states, middle, rule.SurviveMin,
+		rule.SurviveMax, rule.BirthMin, rule.BirthMax, neighborhood)
+}
+
+//ltlTables are the summed-area tables of the world (padded by the range on every side, looking across the seams of
+//the topology), kept around between ticks. counts holds the number of alive cells, and red/green/blue the sum of
+//their colors, which are only computed when blending colors
+type ltlTables struct {
+	width  int
+	counts []uint32
+	red    []uint32
+	green  []uint32
+	blue   []uint32
+}
+
+func (world *World) computeLtLTables(blendColors bool) {
+	r := int64(world.ltl.Range)
+	paddedHeight := int(world.height) + 2*int(r)
+	paddedWidth := int(world.width) + 2*int(r)
+	//the tables have an extra row and column of zeros at the top and left
+	width := paddedWidth + 1
+	size := (paddedHeight + 1) * width
+
+	tables := world.ltlTables
+	if tables == nil || len(tables.counts) != size {
+		tables = &ltlTables{
+			width:  width,
+			counts: make([]uint32, size),
+			red:    make([]uint32, size),
+			green:  make([]uint32, size),
+			blue:   make([]uint32, size),
+		}
+		world.ltlTables = tables
+	}
+
+	for y := 0; y < paddedHeight; y++ {
+		rowCount := uint32(0)
+		rowRed, rowGreen, rowBlue := uint32(0), uint32(0), uint32(0)
+		idx := (y+1)*width + 1
+		for x := 0; x < paddedWidth; x++ {
+			wy, wx := int64(y)-r, int64(x)-r
+			var cell uint32
+			if wy >= 0 && wy < int64(world.height) && wx >= 0 && wx < int64(world.width) {
+				cell = (*world.data)[wy][wx]
+			} else {
+				cell = world.cellAt(wy, wx)
+			}
+			if isAliveBool(cell) {
+				rowCount++
+				if blendColors {
+					rowRed += (cell >> 24) & 0xFF
+					rowGreen += (cell >> 16) & 0xFF
+					rowBlue += (cell >> 8) & 0xFF
+				}
+			}
+			tables.counts[idx+x] = tables.counts[idx+x-width] + rowCount
+			if blendColors {
+				tables.red[idx+x] = tables.red[idx+x-width] + rowRed
+				tables.green[idx+x] = tables.green[idx+x-width] + rowGreen
+				tables.blue[idx+x] = tables.blue[idx+x-width] + rowBlue
+			}
+		}
+	}
+}
+
+//sum of the table over the rectangle from (minY, minX) to (maxY, maxX) inclusive, in padded coordinates
+func (tables *ltlTables) rectSum(table []uint32, minY, minX, maxY, maxX int) uint32 {
+	w := tables.width
+	return table[(maxY+1)*w+maxX+1] - table[minY*w+maxX+1] - table[(maxY+1)*w+minX] + table[minY*w+minX]
+}
+
+//neighborhoodSum sums the table over the neighborhood of the cell at (y, x) in world coordinates
+func (world *World) neighborhoodSum(table []uint32, y, x int) uint32 {
+	r := int(world.ltl.Range)
+	//in padded coordinates, the cell is at (y+r, x+r)
+	if world.ltl.Neighborhood == MOORE {
+		return world.ltlTables.rectSum(table, y, x, y+2*r, x+2*r)
+	}
+	sum := uint32(0)
+	for dy := -r; dy <= r; dy++ {
+		halfWidth := r - abs(dy)
+		sum += world.ltlTables.rectSum(table, y+r+dy, x+r-halfWidth, y+r+dy, x+r+halfWidth)
+	}
+	return sum
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func (world *World) ltlWorker(minY, maxY uint32) {
+	rule := world.ltl
+	blends := world.colors.Blends()
+	//the neighborhood given to the color rule for births, when it doesn't blend
+	grid := make(DataGrid, 3)
+	for i := range grid {
+		grid[i] = make([]uint32, 3)
+	}
+	for y := minY; y < maxY; y++ {
+		for x := uint32(0); x < world.width; x++ {
+			cell := (*world.data)[y][x]
+			count := world.neighborhoodSum(world.ltlTables.counts, int(y), int(x))
+			alive := isAliveBool(cell)
+			if alive && !rule.Middle {
+				count--
+			}
+
+			if alive {
+				if count >= rule.SurviveMin && count <= rule.SurviveMax {
+					if blends {
+						(*world.dataBuffer)[y][x] = Decay(cell)
+					} else {
+						(*world.dataBuffer)[y][x] = world.colors.Survive(world.data, y, x, 0)
+					}
+					if world.states > 2 {
+						(*world.dataBuffer)[y][x] |= ALIVE_NEW
+					}
+				} else if world.states > 2 {
+					(*world.dataBuffer)[y][x] = world.nextRefractoryState(cell)
+				} else {
+					(*world.dataBuffer)[y][x] = DEAD
+				}
+			} else if cell != DEAD {
+				(*world.dataBuffer)[y][x] = world.nextRefractoryState(cell)
+			} else if count >= rule.BirthMin && count <= rule.BirthMax {
+				(*world.dataBuffer)[y][x] = world.ltlBirth(int(y), int(x), count, blends, &grid)
+			} else {
+				(*world.dataBuffer)[y][x] = DEAD
+			}
+		}
+	}
+}
+
+//ltlBirth is a new cell of the average color of the alive cells in the neighborhood when the color rule blends.
+//Otherwise, it's the cell the color rule gives birth to from the most common color in the neighborhood, passed to it as
+//the only (north) neighbor of the birth, with ties going to the color found first. With B0, a cell born without any
+//parents is the one the color rule gives birth to from no neighbors, as in Life-like rules
+func (world *World) ltlBirth(y, x int, count uint32, blends bool, grid *DataGrid) uint32 {
+	tables := world.ltlTables
+	if count == 0 {
+		(*grid)[0][1] = DEAD
+		return world.colors.Born(grid, 1, 1, 0)
+	}
+	if blends {
+		red := world.neighborhoodSum(tables.red, y, x) / count
+		green := world.neighborhoodSum(tables.green, y, x) / count
+		blue := world.neighborhoodSum(tables.blue, y, x) / count
+		return red<<24 | green<<16 | blue<<8 | ALIVE_NEW
+	}
+	colors := make([]uint32, 0)
+	counts := make([]int, 0)
+	best := 0
+	r := int(world.ltl.Range)
+	for dy := -r; dy <= r; dy++ {
+		halfWidth := r
+		if world.ltl.Neighborhood == VON_NEUMANN {
+			halfWidth = r - abs(dy)
+		}
+		for dx := -halfWidth; dx <= halfWidth; dx++ {
+			cell := world.cellAt(int64(y+dy), int64(x+dx))
+			if !isAliveBool(cell) {
+				continue
+			}
+			j := 0
+			for j < len(colors) && colors[j] != cell&^ALIVE_NEW {
+				j++
+			}
+			if j == len(colors) {
+				colors = append(colors, cell&^ALIVE_NEW)
+				counts = append(counts, 0)
+			}
+			counts[j]++
+			if counts[j] > counts[best] {
+				best = j
+			}
+		}
+	}
+	if len(colors) == 0 {
+		return DEAD
+	}
+	(*grid)[0][1] = colors[best] | ALIVE_NEW
+	//only the north neighbor is alive
+	return world.colors.Born(grid, 1, 1, 0x02)
+}
+
+func (world *World) tickLargerThanLife() {
+	world.computeLtLTables(world.colors.Blends())
+
+	stripes := world.stripes
+	world.workerPool().run(len(stripes), func(i int) {
+		world.ltlWorker(stripes[i][0], stripes[i][1])
+	})
+	world.swapBuffers()
+	world.dataChanged()
+}
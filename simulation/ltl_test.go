@@ -0,0 +1,168 @@
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParseLtLRule(t *testing.T) {
+	rule, err := ParseLtLRule("R5,C0,M1,S34..58,B34..45,NM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := LtLRule{Range: 5, States: 2, Middle: true, SurviveMin: 34, SurviveMax: 58, BirthMin: 34, BirthMax: 45,
+		Neighborhood: MOORE}
+	if rule != expected {
+		t.Errorf("Parsed %+v", rule)
+	}
+	if rule.String() != "R5,C0,M1,S34..58,B34..45,NM" {
+		t.Errorf("Got %s", rule.String())
+	}
+
+	rule, err = ParseLtLRule("r2,c3,m0,s5,b3..4,nn")
+	if err != nil || rule.States != 3 || rule.Neighborhood != VON_NEUMANN || rule.SurviveMax != 5 {
+		t.Errorf("Parsed %+v", rule)
+	}
+
+	for _, str := range []string{"R0,S1..2,B3..4", "R5,S1..2", "R5,S2..1,B3..4", "R5,S1..2,B3..4,NX", "R5,R5,S1,B1"} {
+		_, err := ParseLtLRule(str)
+		if err == nil {
+			t.Errorf("Expected an error parsing %s", str)
+		}
+	}
+}
+
+//brute force reference of a single LtL generation
+func ltlReference(world *World, rule LtLRule) map[[2]uint32]bool {
+	r := int(rule.Range)
+	next := make(map[[2]uint32]bool)
+	for y := 0; y < int(world.height); y++ {
+		for x := 0; x < int(world.width); x++ {
+			count := uint32(0)
+			for dy := -r; dy <= r; dy++ {
+				for dx := -r; dx <= r; dx++ {
+					if rule.Neighborhood == VON_NEUMANN && abs(dy)+abs(dx) > r {
+						continue
+					}
+					if (dy != 0 || dx != 0 || rule.Middle) && isAliveBool(world.cellAt(int64(y+dy), int64(x+dx))) {
+						count++
+					}
+				}
+			}
+			alive := isAliveBool((*world.data)[y][x])
+			if (alive && count >= rule.SurviveMin && count <= rule.SurviveMax) ||
+				(!alive && count >= rule.BirthMin && count <= rule.BirthMax) {
+				next[[2]uint32{uint32(y), uint32(x)}] = true
+			}
+		}
+	}
+	return next
+}
+
+func TestWorld_TickLargerThanLife(t *testing.T) {
+	for _, rulestring := range []string{"R1,C0,M0,S2..3,B3..3,NM", "R3,C0,M1,S5..20,B6..12,NM", "R2,C0,M0,S3..6,B4..5,NN"} {
+		for _, topology := range []Topology{BOUNDED, TORUS, KLEIN_BOTTLE} {
+			world, err := NewWorldWithRule(40, 50, rulestring)
+			if err != nil {
+				t.Fatal(err)
+			}
+			world.SetTopology(topology)
+			random := rand.New(rand.NewSource(1))
+			for y := uint32(0); y < world.height; y++ {
+				for x := uint32(0); x < world.width; x++ {
+					if random.Intn(3) == 0 {
+						world.MarkAlive(y, x)
+					}
+				}
+			}
+			for i := 0; i < 5; i++ {
+				expected := ltlReference(&world, *world.ltl)
+				if i%2 == 0 {
+					world.SetColorRule(RGBBlend{})
+				} else {
+					world.SetColorRule(MajorityColors{})
+				}
+				world.Tick()
+				actual := aliveCells(&world)
+				if len(actual) != len(expected) {
+					t.Fatalf("%s on %s: expected %d cells at tick %d, got %d", rulestring, topology, len(expected),
+						world.GetTick(), len(actual))
+				}
+				for cell := range expected {
+					if !actual[cell] {
+						t.Fatalf("%s on %s: expected %v to be alive at tick %d", rulestring, topology, cell, world.GetTick())
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestWorld_TickLargerThanLifeB0(t *testing.T) {
+	for _, colors := range []ColorRule{RGBBlend{}, MajorityColors{}} {
+		ltl, err := NewWorldWithRule(20, 20, "R1,C0,M0,S2..3,B0..0,NM")
+		if err != nil {
+			t.Fatal(err)
+		}
+		lifeLike, _ := NewWorldWithRule(20, 20, "B0/S23")
+		ltl.SetColorRule(colors)
+		lifeLike.SetColorRule(colors)
+
+		//an empty world is filled in one tick, as by the Life-like rule
+		ltl.Tick()
+		if len(aliveCells(&ltl)) != 400 {
+			t.Fatalf("%s: expected every cell to be born, got %d", colors.Name(), len(aliveCells(&ltl)))
+		}
+		lifeLike.Tick()
+		for y := uint32(0); y < 20; y++ {
+			for x := uint32(0); x < 20; x++ {
+				if (*ltl.data)[y][x] != (*lifeLike.data)[y][x] {
+					t.Fatalf("%s: expected (%d, %d) to be born as %08x, got %08x", colors.Name(), y, x,
+						(*lifeLike.data)[y][x], (*ltl.data)[y][x])
+				}
+			}
+		}
+		ltl.Tick()
+		lifeLike.Tick()
+		if len(aliveCells(&ltl)) != len(aliveCells(&lifeLike)) {
+			t.Errorf("%s: expected %d cells, got %d", colors.Name(), len(aliveCells(&lifeLike)), len(aliveCells(&ltl)))
+		}
+	}
+}
+
+func TestWorld_TickLargerThanLifeMatchesConway(t *testing.T) {
+	conway := NewConwayWorld(30, 30)
+	ltl, _ := NewWorldWithRule(30, 30, "R1,C0,M0,S2..3,B3..3,NM")
+	conway.PlaceRLEAtCoords(glider, 5, 5, FULL)
+	ltl.PlaceRLEAtCoords(glider, 5, 5, FULL)
+	for i := 0; i < 20; i++ {
+		conway.Tick()
+		ltl.Tick()
+	}
+	expected := aliveCells(&conway)
+	actual := aliveCells(&ltl)
+	if len(expected) != 5 || len(actual) != len(expected) {
+		t.Fatalf("Expected %d cells, got %d", len(expected), len(actual))
+	}
+	for cell := range expected {
+		if !actual[cell] {
+			t.Errorf("Expected %v to be alive", cell)
+		}
+	}
+}
+
+func BenchmarkWorld_TickLargerThanLife(b *testing.B) {
+	world, _ := NewWorldWithRule(400, 750, "R5,C0,M1,S34..58,B34..45,NM")
+	random := rand.New(rand.NewSource(1))
+	for y := uint32(0); y < world.height; y++ {
+		for x := uint32(0); x < world.width; x++ {
+			if random.Intn(2) == 0 {
+				world.MarkAlive(y, x)
+			}
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.Tick()
+	}
+}
@@ -0,0 +1,255 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+//MargolusRule is a block cellular automaton on the Margolus neighborhood: the world is split into 2x2 blocks, and the
+//table gives the block every block becomes. The bits of a block are its top-left (1), top-right (2), bottom-left (4)
+//and bottom-right (8) cells. The blocks of even ticks have their top-left corner on even rows and columns, and the
+//blocks of odd ticks are offset by one cell diagonally
+type MargolusRule struct {
+	Name  string
+	Table [16]uint8
+}
+
+//rotate180 turns a block upside down, swapping the cells on both of its diagonals
+func rotate180(block uint8) uint8 {
+	return block&1<<3 | block&8>>3 | block&2<<1 | block&4>>1
+}
+
+//Critters keeps blocks with 2 alive cells, and inverts the others, also turning the ones that had 3 alive cells upside
+//down
+func crittersTable() [16]uint8 {
+	var table [16]uint8
+	for block := uint8(0); block < 16; block++ {
+		switch bits.OnesCount8(block) {
+		case 2:
+			table[block] = block
+		case 3:
+			table[block] = rotate180(15 ^ block)
+		default:
+			table[block] = 15 ^ block
+		}
+	}
+	return table
+}
+
+//the Billiard Ball Machine moves lone balls (alive cells) to the opposite corner of their block, and turns balls that
+//collide head-on (on a diagonal) onto the other diagonal
+func billiardBallTable() [16]uint8 {
+	var table [16]uint8
+	for block := uint8(0); block < 16; block++ {
+		table[block] = block
+		if bits.OnesCount8(block) == 1 {
+			table[block] = rotate180(block)
+		}
+	}
+	table[0b1001], table[0b0110] = 0b0110, 0b1001
+	return table
+}
+
+//Tron inverts the blocks that are all alive or all dead
+func tronTable() [16]uint8 {
+	var table [16]uint8
+	for block := uint8(0); block < 16; block++ {
+		table[block] = block
+	}
+	table[0], table[15] = 15, 0
+	return table
+}
+
+//MargolusRules are the block rules that can be run by name, keyed by their lowercase name
+var MargolusRules = map[string]MargolusRule{
+	"critters": {Name: "Critters", Table: crittersTable()},
+	"bbm":      {Name: "BBM", Table: billiardBallTable()},
+	"tron":     {Name: "Tron", Table: tronTable()},
+}
+
+//IsMargolusRule returns whether the rulestring is a named block rule, or a block table in M notation
+func IsMargolusRule(rulestring string) bool {
+	if _, ok := MargolusRules[strings.ToLower(rulestring)]; ok {
+		return true
+	}
+	return len(rulestring) > 1 && (rulestring[0] == 'M' || rulestring[0] == 'm') && rulestring[1] >= '0' &&
+		rulestring[1] <= '9'
+}
+
+//ParseMargolusRule parses a named block rule (Critters, BBM or Tron), or the 16 entries of a block table in M notation,
+//such as "M15,1,2,3,4,5,6,7,8,9,10,11,12,13,14,0" for Tron
+func ParseMargolusRule(rulestring string) (MargolusRule, error) {
+	if rule, ok := MargolusRules[strings.ToLower(rulestring)]; ok {
+		return rule, nil
+	}
+	if !IsMargolusRule(rulestring) {
+		return MargolusRule{}, fmt.Errorf("%s isn't a block rule", rulestring)
+	}
+	entries := strings.Split(rulestring[1:], ",")
+	if len(entries) != 16 {
+		return MargolusRule{}, fmt.Errorf("a block rule needs 16 entries, got %d", len(entries))
+	}
+	rule := MargolusRule{}
+	for i, entry := range entries {
+		block, err := strconv.ParseUint(strings.TrimSpace(entry), 10, 8)
+		if err != nil || block > 15 {
+			return MargolusRule{}, fmt.Errorf("invalid block %s", entry)
+		}
+		rule.Table[i] = uint8(block)
+	}
+	rule.Name = rule.String()
+	return rule, nil
+}
+
+func (rule MargolusRule) String() string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	entries := make([]string, len(rule.Table))
+	for i, block := range rule.Table {
+		entries[i] = strconv.Itoa(int(block))
+	}
+	return "M" + strings.Join(entries, ",")
+}
+
+//Reversible returns whether every block comes from a single block, so the rule can be run backward
+func (rule MargolusRule) Reversible() bool {
+	var seen [16]bool
+	for _, block := range rule.Table {
+		if seen[block] {
+			return false
+		}
+		seen[block] = true
+	}
+	return true
+}
+
+func (rule MargolusRule) inverse() [16]uint8 {
+	var inverse [16]uint8
+	for block, next := range rule.Table {
+		inverse[next] = uint8(block)
+	}
+	return inverse
+}
+
+//IsReversible returns whether the world runs a reversible block rule, which TickBackward can run backward
+func (world *World) IsReversible() bool {
+	return world.margolus != nil && world.margolus.Reversible()
+}
+
+//tickBlocks updates every block of the tick's partition with the rule
+func (world *World) tickBlocks() {
+	world.updateBlocks(&world.margolus.Table, int(world.tick%2))
+	world.swapBuffers()
+	world.dataChanged()
+}
+
+//updateBlocks updates every block of the partition of the phase with the table, into the data buffer
+func (world *World) updateBlocks(table *[16]uint8, phase int) {
+	//the blocks only wrap around a torus when they tile it exactly
+	wrap := world.topology == TORUS && world.height%2 == 0 && world.width%2 == 0
+	stripes := world.stripes
+	world.workerPool().run(len(stripes), func(i int) {
+		world.margolusWorker(stripes[i][0], stripes[i][1], table, phase, wrap)
+	})
+}
+
+//margolusWorker updates the blocks whose top row is in the stripe. Unless they wrap around, the blocks cut off by the
+//edges of the world are left as they are, so the rule stays reversible
+func (world *World) margolusWorker(minY, maxY uint32, table *[16]uint8, phase int, wrap bool) {
+	start := phase
+	if !wrap && phase == 1 {
+		//the cut-off blocks along the top and left edges
+		start = -1
+	}
+	for by := start; by < int(world.height); by += 2 {
+		if top := max(by, 0); top < int(minY) || top >= int(maxY) {
+			continue
+		}
+		for bx := start; bx < int(world.width); bx += 2 {
+			world.margolusBlock(by, bx, table, wrap)
+		}
+	}
+}
+
+//margolusBlock updates the block with its top-left corner at (by, bx) into the data buffer. Alive cells that stay
+//alive age, and cells that become alive take the color of the first alive cell of the block, or white in empty blocks
+func (world *World) margolusBlock(by, bx int, table *[16]uint8, wrap bool) {
+	height, width := int(world.height), int(world.width)
+	var coords [4][2]int
+	complete := true
+	for i := range coords {
+		y, x := by+i/2, bx+i%2
+		if wrap {
+			y, x = y%height, x%width
+		} else if y < 0 || x < 0 || y >= height || x >= width {
+			complete = false
+		}
+		coords[i] = [2]int{y, x}
+	}
+	if !complete {
+		for _, c := range coords {
+			if c[0] >= 0 && c[1] >= 0 && c[0] < height && c[1] < width {
+				(*world.dataBuffer)[c[0]][c[1]] = (*world.data)[c[0]][c[1]]
+			}
+		}
+		return
+	}
+
+	block := uint8(0)
+	color := FULL &^ ALIVE_NEW
+	found := false
+	for i, c := range coords {
+		if cell := (*world.data)[c[0]][c[1]]; isAliveBool(cell) {
+			block |= 1 << i
+			if !found {
+				color, found = cell&^ALIVE_NEW, true
+			}
+		}
+	}
+	next := table[block]
+	for i, c := range coords {
+		cell := (*world.data)[c[0]][c[1]]
+		if next>>i&1 == 0 {
+			(*world.dataBuffer)[c[0]][c[1]] = DEAD
+		} else if isAliveBool(cell) {
+			(*world.dataBuffer)[c[0]][c[1]] = Decay(cell)
+		} else {
+			(*world.dataBuffer)[c[0]][c[1]] = color | ALIVE_NEW
+		}
+	}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+//TickBackward moves a world running a reversible block rule back to the generation before its current one. Which
+//cells are alive is exactly the generation before, but colors and ages are only kept as far as the history goes: the
+//history is used while it has the generation before (and the world hasn't been edited since), and is otherwise
+//restarted, along with the stats, from the generation computed by running the rule backward
+func (world *World) TickBackward() error {
+	if !world.IsReversible() {
+		return errors.New("only reversible block rules can run backward")
+	}
+	if world.tick == 0 {
+		return errors.New("the world can't go back before tick 0")
+	}
+	if world.history != nil && !world.history.stale && world.StepBack() {
+		return nil
+	}
+	//the blocks of the generation before are the ones of its phase
+	inverse := world.margolus.inverse()
+	world.updateBlocks(&inverse, int((world.tick-1)%2))
+	world.data, world.dataBuffer = world.dataBuffer, world.data
+	world.tick--
+	world.edited()
+	world.restartTracking()
+	return nil
+}
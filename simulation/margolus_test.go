@@ -0,0 +1,145 @@
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParseMargolusRule(t *testing.T) {
+	for _, name := range []string{"Critters", "bbm", "TRON"} {
+		rule, err := ParseMargolusRule(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !rule.Reversible() {
+			t.Errorf("expected %s to be reversible", rule)
+		}
+	}
+	rule, err := ParseMargolusRule("M15,1,2,3,4,5,6,7,8,9,10,11,12,13,14,0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Table != MargolusRules["tron"].Table || rule.String() != "M15,1,2,3,4,5,6,7,8,9,10,11,12,13,14,0" {
+		t.Errorf("expected the table of Tron, got %s", rule)
+	}
+	for _, rulestring := range []string{"M1,2,3", "M0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,16", "Mx"} {
+		if _, err := ParseMargolusRule(rulestring); err == nil {
+			t.Errorf("expected an error parsing %s", rulestring)
+		}
+	}
+	if _, err := NewSparseWorld("Critters"); err == nil {
+		t.Error("expected an error running a block rule on an unbounded world")
+	}
+}
+
+func TestWorld_BilliardBall(t *testing.T) {
+	world, err := NewWorldWithRule(20, 20, "BBM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	world.SetCell(4, 4, 0xFF0000FF)
+	//a lone ball moves to the opposite corner of its block every tick, which is the top-left corner of its next block
+	for i := 0; i < 6; i++ {
+		world.Tick()
+	}
+	snapshot := world.Snapshot()
+	if len(snapshot.Cells) != 1 || !isAliveBool(world.GetCell(10, 10)) || world.GetCell(10, 10)&^0xFF != 0xFF000000 {
+		t.Fatalf("expected the ball to have moved to (10, 10), got %v", snapshot.Cells)
+	}
+	if err := world.TickBackward(); err != nil {
+		t.Fatal(err)
+	}
+	if world.GetTick() != 5 || !isAliveBool(world.GetCell(9, 9)) {
+		t.Fatal("expected the ball to move back")
+	}
+}
+
+func TestWorld_TickBackward(t *testing.T) {
+	for _, test := range []struct {
+		topology      Topology
+		height, width uint32
+	}{
+		{TORUS, 32, 40},
+		{BOUNDED, 31, 33},
+		//the blocks don't wrap around a torus they don't tile
+		{TORUS, 33, 32},
+	} {
+		world, err := NewWorldWithRule(test.height, test.width, "Critters")
+		if err != nil {
+			t.Fatal(err)
+		}
+		world.SetTopology(test.topology)
+		random := rand.New(rand.NewSource(3))
+		for y := int64(0); y < int64(test.height); y++ {
+			for x := int64(0); x < int64(test.width); x++ {
+				if random.Intn(2) == 0 {
+					world.SetCell(y, x, 0x00FF00FF)
+				}
+			}
+		}
+		start := copyData(&world)
+		for i := 0; i < 100; i++ {
+			world.Tick()
+		}
+		for i := 0; i < 100; i++ {
+			if err := world.TickBackward(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if world.GetTick() != 0 {
+			t.Fatalf("expected to be back at tick 0, got %d", world.GetTick())
+		}
+		for y := uint32(0); y < test.height; y++ {
+			for x := uint32(0); x < test.width; x++ {
+				if isAliveBool(world.GetCell(int64(y), int64(x))) != isAliveBool(start[y][x]) {
+					t.Fatalf("%s %dx%d: expected (%d, %d) to be as it started", test.topology, test.height, test.width,
+						y, x)
+				}
+			}
+		}
+		if err := world.TickBackward(); err == nil {
+			t.Error("expected an error going back before tick 0")
+		}
+	}
+}
+
+func TestWorld_TickBackwardHistory(t *testing.T) {
+	world, _ := NewWorldWithRule(20, 20, "Critters")
+	if err := world.EnableHistory(10, 4); err != nil {
+		t.Fatal(err)
+	}
+	world.PlaceRLEAtCoords(glider, 8, 8, 0xFF000000)
+	world.PlaceRLEAtCoords(glider, 8, 12, 0x0000FF00)
+	for i := 0; i < 5; i++ {
+		world.Tick()
+	}
+	expected := copyData(&world)
+	world.Tick()
+	//the history has the generation before, colors and all
+	if err := world.TickBackward(); err != nil {
+		t.Fatal(err)
+	}
+	checkData(t, &world, expected)
+
+	//once the world is edited, the history and stats start over from the generation computed backward
+	world.EnableStats(10)
+	world.Tick()
+	world.MarkAlive(0, 0)
+	if err := world.TickBackward(); err != nil {
+		t.Fatal(err)
+	}
+	oldest, newest, _ := world.GetHistoryRange()
+	if oldest != world.GetTick() || newest != world.GetTick() || len(world.GetStats()) != 0 {
+		t.Errorf("Expected the history and stats to start over at tick %d, got history %d to %d and %d stats",
+			world.GetTick(), oldest, newest, len(world.GetStats()))
+	}
+
+	notReversible, _ := NewWorldWithRule(20, 20, "M0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0")
+	notReversible.Tick()
+	if notReversible.IsReversible() || notReversible.TickBackward() == nil {
+		t.Error("expected a rule that isn't reversible not to run backward")
+	}
+	if conway := NewConwayWorld(20, 20); conway.IsReversible() {
+		t.Error("expected Conway's rule not to be reversible")
+	}
+}
@@ -74,6 +74,10 @@ func (dg *DataGrid) ExistingCellNeighborsColorBlend(oldCell uint32, y, x uint32,
 }
 
 func (dg DataGrid) NeighborsColorMajority(y, x uint32, neighbors byte) uint32 {
+	//when every neighbor has the same color, it's the majority without having to count them
+	if color, ok := dg.uniformNeighborsColor(y, x, neighbors); ok {
+		return newCellOfColor(colorOfCell(color))
+	}
 	colorCount := make(map[colorful.Color]int)
 	for i := N; i < 9; i++ {
 		if neighbors&DirectionMasks[i] > 0 {
@@ -87,18 +91,40 @@ func (dg DataGrid) NeighborsColorMajority(y, x uint32, neighbors byte) uint32 {
 	lastColor := colorful.Color{}
 	for col, count := range colorCount {
 		if count == 2 || count > 2 {
-			newRed := col.R * 255.0
-			newGreen := col.G * 255.0
-			newBlue := col.B * 255.0
-			return uint32(newRed)<<24 + uint32(newGreen)<<16 + uint32(newBlue)<<8 + ALIVE_NEW
+			return newCellOfColor(col)
 		} else {
 			lastColor = col
 		}
 	}
 	//Just pick the last color in the map (maps aren't sorted, so this should be relatively random)
-	newRed := lastColor.R * 255.0
-	newGreen := lastColor.G * 255.0
-	newBlue := lastColor.B * 255.0
+	return newCellOfColor(lastColor)
+}
+
+//the offsets of the neighbors, in the order of their bits in the neighborhood (NW first)
+var neighborOffsets = [8][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}}
+
+//uniformNeighborsColor returns the color of the neighbors if there's at least one, and they all have the same color
+func (dg DataGrid) uniformNeighborsColor(y, x uint32, neighbors byte) (uint32, bool) {
+	color := uint32(0)
+	found := false
+	for i, offset := range neighborOffsets {
+		if neighbors>>i&1 == 0 {
+			continue
+		}
+		cellColor := dg[int(y)+offset[0]][int(x)+offset[1]] &^ ALIVE_NEW
+		if found && cellColor != color {
+			return 0, false
+		}
+		color = cellColor
+		found = true
+	}
+	return color, found
+}
+
+func newCellOfColor(col colorful.Color) uint32 {
+	newRed := col.R * 255.0
+	newGreen := col.G * 255.0
+	newBlue := col.B * 255.0
 	return uint32(newRed)<<24 + uint32(newGreen)<<16 + uint32(newBlue)<<8 + ALIVE_NEW
 }
 
@@ -150,57 +176,41 @@ var DirectionMasks = map[Direction]byte{
 	W:  0b1000_0000,
 }
 
-//Northwest is the right-most bit of the byte, rotating around clockwise until the left side of the byte
-//So the neighborhood can be interpreted as so:
-//0b_W_SW_S_SE_E_NE_N_NW
+type NeighborhoodType int
 
-func (dg DataGrid) PerimeterNeighborsValue(dir Direction, y, x uint32) byte {
-	neighborState := byte(0)
-	switch dir {
-	case N:
-		neighborState |= isAlive(dg[y][x+1]) << 3   //E
-		neighborState |= isAlive(dg[y+1][x+1]) << 4 //SE
-		neighborState |= isAlive(dg[y+1][x]) << 5   //S
-		neighborState |= isAlive(dg[y+1][x-1]) << 6 //SW
-		neighborState |= isAlive(dg[y][x-1]) << 7   //W
-	case NE:
-		neighborState |= isAlive(dg[y+1][x]) << 5   //S
-		neighborState |= isAlive(dg[y+1][x-1]) << 6 //SW
-		neighborState |= isAlive(dg[y][x-1]) << 7   //W
-	case E:
-		neighborState |= isAlive(dg[y-1][x-1])      //NW
-		neighborState |= isAlive(dg[y-1][x]) << 1   //N
-		neighborState |= isAlive(dg[y+1][x]) << 5   //S
-		neighborState |= isAlive(dg[y+1][x-1]) << 6 //SW
-		neighborState |= isAlive(dg[y][x-1]) << 7   //W
-	case SE:
-		neighborState |= isAlive(dg[y-1][x-1])    //NW
-		neighborState |= isAlive(dg[y-1][x]) << 1 //N
-		neighborState |= isAlive(dg[y][x-1]) << 7 //W
-	case S:
-		neighborState |= isAlive(dg[y-1][x-1])      //NW
-		neighborState |= isAlive(dg[y-1][x]) << 1   //N
-		neighborState |= isAlive(dg[y-1][x+1]) << 2 //NE
-		neighborState |= isAlive(dg[y][x+1]) << 3   //E
-		neighborState |= isAlive(dg[y][x-1]) << 7   //W
-	case SW:
-		neighborState |= isAlive(dg[y-1][x]) << 1   //N
-		neighborState |= isAlive(dg[y-1][x+1]) << 2 //NE
-		neighborState |= isAlive(dg[y][x+1]) << 3   //E
-	case W:
-		neighborState |= isAlive(dg[y-1][x]) << 1   //N
-		neighborState |= isAlive(dg[y-1][x+1]) << 2 //NE
-		neighborState |= isAlive(dg[y][x+1]) << 3   //E
-		neighborState |= isAlive(dg[y+1][x+1]) << 4 //SE
-		neighborState |= isAlive(dg[y+1][x]) << 5   //S
-	case NW:
-		neighborState |= isAlive(dg[y][x+1]) << 3   //E
-		neighborState |= isAlive(dg[y+1][x+1]) << 4 //SE
-		neighborState |= isAlive(dg[y+1][x]) << 5   //S
+const (
+	//all 8 surrounding cells
+	MOORE NeighborhoodType = 0
+	//only the 4 orthogonally adjacent cells
+	VON_NEUMANN NeighborhoodType = 1
+	//6 cells on a grid where the odd rows are offset by half a cell to the right
+	HEXAGONAL NeighborhoodType = 2
+)
+
+//NeighborhoodMasks returns the neighbors that are part of the neighborhood, for cells on even and odd rows
+func (neighborhood NeighborhoodType) NeighborhoodMasks() [2]byte {
+	switch neighborhood {
+	case VON_NEUMANN:
+		mask := DirectionMasks[N] | DirectionMasks[E] | DirectionMasks[S] | DirectionMasks[W]
+		return [2]byte{mask, mask}
+	case HEXAGONAL:
+		//on even rows, the rows above and below are offset to the right, so the NE and SE cells aren't adjacent, and
+		//on odd rows it's the NW and SW cells
+		return [2]byte{^(DirectionMasks[NE] | DirectionMasks[SE]), ^(DirectionMasks[NW] | DirectionMasks[SW])}
+	default:
+		return [2]byte{0xFF, 0xFF}
 	}
-	return neighborState
 }
 
+//MaxNeighbors is the number of cells in the neighborhood
+func (neighborhood NeighborhoodType) MaxNeighbors() int {
+	return NumNeighbors(neighborhood.NeighborhoodMasks()[0])
+}
+
+//Northwest is the right-most bit of the byte, rotating around clockwise until the left side of the byte
+//So the neighborhood can be interpreted as so:
+//0b_W_SW_S_SE_E_NE_N_NW
+
 func isAlive(cell uint32) byte {
 	if cell&ALIVE_BIT > 0 {
 		return byte(1)
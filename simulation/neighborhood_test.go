@@ -11,10 +11,10 @@ func TestDataGrid_InnerNeighborsValue(t *testing.T) {
 		dg[y] = make([]uint32, 10)
 	}
 
-	dg[3][4] = ALIVE_FULL
-	dg[4][4] = ALIVE_FULL
-	dg[4][3] = ALIVE_FULL
-	dg[4][2] = ALIVE_FULL
+	dg[3][4] = FULL
+	dg[4][4] = FULL
+	dg[4][3] = FULL
+	dg[4][2] = FULL
 
 	val := dg.InnerNeighborsValue(3, 3)
 	log.Print(ConwayIsNextStageAlive(false, val))
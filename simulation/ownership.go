@@ -0,0 +1,54 @@
+package simulation
+
+//In ownership mode, the top 3 bytes of a cell hold the ID of the player that owns it instead of its color, and the lower
+//byte is the same as ever. Owner 0 is nobody
+const MAX_OWNER uint32 = 0xFF_FF_FF
+
+//OwnedCell returns a newly alive cell owned by the owner, to be placed with MarkAliveColor or PlaceRLEAtCoords
+func OwnedCell(owner uint32) uint32 {
+	return (owner&MAX_OWNER)<<8 | ALIVE_NEW
+}
+
+func OwnerOf(cell uint32) uint32 {
+	return cell >> 8
+}
+
+//isOwnership reports whether the cells hold the ID of their owner instead of a color, with the OwnershipColors rule.
+//Born cells are owned by the most common owner of their alive neighbors, and surviving cells keep their owner
+func isOwnership(colors ColorRule) bool {
+	_, ok := colors.(OwnershipColors)
+	return ok
+}
+
+//GetOwnership reports whether the world keeps the owner of every cell, set with SetColorRule(OwnershipColors{})
+func (world *World) GetOwnership() bool {
+	return isOwnership(world.colors)
+}
+
+//NeighborsOwnerMajority returns a new cell owned by the most common owner of the neighbors. A tie goes to the tied owner
+//found first going clockwise from the northwest neighbor, so it doesn't depend on the IDs of the owners
+func (dg DataGrid) NeighborsOwnerMajority(y, x uint32, neighbors byte) uint32 {
+	var owners [8]uint32
+	var counts [8]int
+	found := 0
+	best := 0
+	for i, offset := range neighborOffsets {
+		if neighbors>>i&1 == 0 {
+			continue
+		}
+		owner := OwnerOf(dg[int(y)+offset[0]][int(x)+offset[1]])
+		j := 0
+		for j < found && owners[j] != owner {
+			j++
+		}
+		if j == found {
+			owners[j] = owner
+			found++
+		}
+		counts[j]++
+		if counts[j] > counts[best] {
+			best = j
+		}
+	}
+	return OwnedCell(owners[best])
+}
@@ -0,0 +1,87 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestDataGrid_NeighborsOwnerMajority(t *testing.T) {
+	tests := []struct {
+		grid     DataGrid
+		expected uint32
+	}{
+		{DataGrid{
+			{OwnedCell(1), DEAD, OwnedCell(2)},
+			{DEAD, DEAD, OwnedCell(2)},
+			{DEAD, DEAD, DEAD},
+		}, 2},
+		//ties go to the owner found first, going clockwise from the northwest
+		{DataGrid{
+			{DEAD, OwnedCell(5), DEAD},
+			{OwnedCell(3), DEAD, OwnedCell(4)},
+			{DEAD, DEAD, DEAD},
+		}, 5},
+		{DataGrid{
+			{DEAD, DEAD, DEAD},
+			{OwnedCell(7), DEAD, OwnedCell(9)},
+			{DEAD, OwnedCell(7), OwnedCell(9)},
+		}, 9},
+	}
+	for i, test := range tests {
+		neighborhood := test.grid.InnerNeighborsValue(1, 1)
+		if cell := test.grid.NeighborsOwnerMajority(1, 1, neighborhood); cell != OwnedCell(test.expected) {
+			t.Errorf("test %d: expected owner %d, got %d", i, test.expected, OwnerOf(cell))
+		}
+	}
+}
+
+func TestWorld_Ownership(t *testing.T) {
+	owners := []uint32{OwnedCell(1), OwnedCell(2), OwnedCell(3)}
+	for _, rule := range []string{CONWAY_RULE, "B36/S23", "R2,C0,M1,S2..5,B3..4,NM"} {
+		//the owners of the cells don't depend on the engine or the workers
+		var expected [][]uint32
+		for _, workers := range []uint32{1, 4} {
+			for _, packed := range []bool{true, false} {
+				world, err := NewWorldWithRule(40, 50, rule)
+				if err != nil {
+					t.Fatal(err)
+				}
+				world.SetColorRule(OwnershipColors{})
+				if !packed {
+					world.packed = nil
+				}
+				world.SetWorkers(workers, TILES)
+				randomSoup(&world, 3, owners)
+				for i := 0; i < 40; i++ {
+					world.Tick()
+				}
+				world.Close()
+				for y := uint32(0); y < world.height; y++ {
+					for x := uint32(0); x < world.width; x++ {
+						cell := (*world.data)[y][x]
+						if isAliveBool(cell) && (OwnerOf(cell) < 1 || OwnerOf(cell) > 3) {
+							t.Fatalf("rule %s: cell (%d, %d) has owner %d", rule, y, x, OwnerOf(cell))
+						}
+					}
+				}
+				if expected == nil {
+					expected = copyData(&world)
+				} else {
+					checkData(t, &world, expected)
+				}
+			}
+		}
+	}
+
+	//a lone glider stays with its owner
+	world := NewConwayWorld(30, 30)
+	world.SetColorRule(OwnershipColors{})
+	world.PlaceRLEAtCoords(glider, 2, 2, OwnedCell(42))
+	for i := 0; i < 40; i++ {
+		world.Tick()
+	}
+	for cell := range aliveCells(&world) {
+		if owner := OwnerOf((*world.data)[cell[0]][cell[1]]); owner != 42 {
+			t.Fatalf("expected the glider to be owned by 42, found a cell owned by %d", owner)
+		}
+	}
+}
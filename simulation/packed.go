@@ -0,0 +1,309 @@
+package simulation
+
+import (
+	"math/bits"
+)
+
+//packedGrid holds whether each cell of the world is alive as a single bit, 64 cells to a word, so two-state totalistic
+//rules can be evaluated 64 cells at a time with bit-sliced adders. Every row has an extra cell on either side, and
+//there's an extra row above and below, which hold the cells across the edges of the world (according to its topology)
+type packedGrid struct {
+	height uint32
+	width  uint32
+	//words per row, and the bits of each word that hold cells of the world rather than the extra cells
+	words     int
+	validBits []uint64
+	current   []uint64
+	next      []uint64
+	//the neighbor counts that cause a birth or a survival
+	birthCounts   []int
+	surviveCounts []int
+	//false when the world's data has changed since it was last packed
+	synced bool
+}
+
+//newPackedGrid returns nil if the rule can't be evaluated by counting neighbors
+func newPackedGrid(height, width uint32, rules cellRules) *packedGrid {
+	if rules.states > 2 || height < 2 || width < 2 {
+		return nil
+	}
+	var birth, survive [9]bool
+	seen := [9]bool{}
+	for n := 0; n < 256; n++ {
+		count := NumNeighbors(byte(n))
+		if seen[count] && (birth[count] != rules.deadRulesMapping[byte(n)] || survive[count] != rules.aliveRulesMapping[byte(n)]) {
+			return nil
+		}
+		seen[count] = true
+		birth[count] = rules.deadRulesMapping[byte(n)]
+		survive[count] = rules.aliveRulesMapping[byte(n)]
+	}
+	packed := &packedGrid{
+		height: height,
+		width:  width,
+		words:  int(width+2+63) / 64,
+	}
+	for count := 0; count <= 8; count++ {
+		if birth[count] {
+			packed.birthCounts = append(packed.birthCounts, count)
+		}
+		if survive[count] {
+			packed.surviveCounts = append(packed.surviveCounts, count)
+		}
+	}
+	packed.validBits = make([]uint64, packed.words)
+	for p := uint32(1); p <= width; p++ {
+		packed.validBits[p/64] |= 1 << (p % 64)
+	}
+	packed.current = make([]uint64, packed.words*int(height+2))
+	packed.next = make([]uint64, packed.words*int(height+2))
+	return packed
+}
+
+//bit position p of a row holds the cell at x = p-1
+func (packed *packedGrid) set(cells []uint64, row int, p uint32, alive bool) {
+	idx := row*packed.words + int(p/64)
+	if alive {
+		cells[idx] |= 1 << (p % 64)
+	} else {
+		cells[idx] &^= 1 << (p % 64)
+	}
+}
+
+func (packed *packedGrid) pack(data *DataGrid) {
+	for y := uint32(0); y < packed.height; y++ {
+		row := packed.current[int(y+1)*packed.words : int(y+2)*packed.words]
+		for i := range row {
+			row[i] = 0
+		}
+		for x := uint32(0); x < packed.width; x++ {
+			if isAliveBool((*data)[y][x]) {
+				row[(x+1)/64] |= 1 << ((x + 1) % 64)
+			}
+		}
+	}
+	packed.synced = true
+}
+
+//fillPadding copies the cells across the edges of the world into the extra rows and columns
+func (packed *packedGrid) fillPadding(world *World) {
+	h, w := int64(packed.height), int64(packed.width)
+	for x := int64(-1); x <= w; x++ {
+		packed.set(packed.current, 0, uint32(x+1), isAliveBool(world.cellAt(-1, x)))
+		packed.set(packed.current, int(h+1), uint32(x+1), isAliveBool(world.cellAt(h, x)))
+	}
+	for y := int64(0); y < h; y++ {
+		packed.set(packed.current, int(y+1), 0, isAliveBool(world.cellAt(y, -1)))
+		packed.set(packed.current, int(y+1), uint32(w+1), isAliveBool(world.cellAt(y, w)))
+	}
+}
+
+//adds three 1-bit numbers in each bit position
+func fullAdd(a, b, c uint64) (sum, carry uint64) {
+	return a ^ b ^ c, a&b | a&c | b&c
+}
+
+//countEquals selects the bit positions where the neighbor count, given as its 4 bits, equals count
+func countEquals(ones, twos, fours, eights uint64, count int) uint64 {
+	match := ^uint64(0)
+	for bit, value := range [4]uint64{ones, twos, fours, eights} {
+		if count>>bit&1 == 1 {
+			match &= value
+		} else {
+			match &^= value
+		}
+	}
+	return match
+}
+
+//tickRows computes the next state of the rows [minY, maxY) of the world
+func (packed *packedGrid) tickRows(minY, maxY uint32, masks [2]byte) {
+	words := packed.words
+	for y := minY; y < maxY; y++ {
+		//every neighbor outside of the neighborhood is masked out of the sum
+		var selected [8]uint64
+		for i := range selected {
+			if masks[y&1]>>i&1 == 1 {
+				selected[i] = ^uint64(0)
+			}
+		}
+		above := packed.current[int(y)*words : int(y+1)*words]
+		row := packed.current[int(y+1)*words : int(y+2)*words]
+		below := packed.current[int(y+2)*words : int(y+3)*words]
+		out := packed.next[int(y+1)*words : int(y+2)*words]
+
+		for i := 0; i < words; i++ {
+			//the cells to the west of each position are shifted in from the previous word, and to the east from the next
+			var prevAbove, prevRow, prevBelow, nextAbove, nextRow, nextBelow uint64
+			if i > 0 {
+				prevAbove, prevRow, prevBelow = above[i-1], row[i-1], below[i-1]
+			}
+			if i < words-1 {
+				nextAbove, nextRow, nextBelow = above[i+1], row[i+1], below[i+1]
+			}
+			nw := (above[i]<<1 | prevAbove>>63) & selected[0]
+			n := above[i] & selected[1]
+			ne := (above[i]>>1 | nextAbove<<63) & selected[2]
+			e := (row[i]>>1 | nextRow<<63) & selected[3]
+			se := (below[i]>>1 | nextBelow<<63) & selected[4]
+			s := below[i] & selected[5]
+			sw := (below[i]<<1 | prevBelow>>63) & selected[6]
+			w := (row[i]<<1 | prevRow>>63) & selected[7]
+
+			//sum the 8 neighbors into a 4-bit count in every bit position
+			s0, c0 := fullAdd(nw, n, ne)
+			s1, c1 := fullAdd(e, se, s)
+			s2, c2 := sw^w, sw&w
+			ones, c3 := fullAdd(s0, s1, s2)
+			t0, k0 := fullAdd(c0, c1, c2)
+			twos, k1 := t0^c3, t0&c3
+			fours, eights := k0^k1, k0&k1
+
+			alive := row[i]
+			var born, survived uint64
+			for _, count := range packed.birthCounts {
+				born |= countEquals(ones, twos, fours, eights, count)
+			}
+			for _, count := range packed.surviveCounts {
+				survived |= countEquals(ones, twos, fours, eights, count)
+			}
+			out[i] = (alive&survived | ^alive&born) & packed.validBits[i]
+		}
+	}
+}
+
+//forEachCell calls f with every cell of the rows [minY, maxY) that is alive in either the current or the next state
+func (packed *packedGrid) forEachCell(minY, maxY uint32, f func(y, x uint32, alive, nextAlive bool)) {
+	words := packed.words
+	for y := minY; y < maxY; y++ {
+		for i := 0; i < words; i++ {
+			idx := int(y+1)*words + i
+			current := packed.current[idx] & packed.validBits[i]
+			next := packed.next[idx]
+			changed := current | next
+			for changed != 0 {
+				bit := uint64(bits.TrailingZeros64(changed))
+				changed &^= 1 << bit
+				x := uint32(i)*64 + uint32(bit) - 1
+				f(y, x, current>>bit&1 == 1, next>>bit&1 == 1)
+			}
+		}
+	}
+}
+
+//tickPacked evaluates the rule on the packed grid, and then only updates the cells of the world that were or become
+//alive. Colors and ages are computed exactly as by the other workers when the color rule doesn't blend
+func (world *World) tickPacked() {
+	packed := world.packed
+	if !packed.synced {
+		packed.pack(world.data)
+	}
+	packed.fillPadding(world)
+
+	pool := world.workerPool()
+	stripes := world.stripes
+	pool.run(len(stripes), func(i int) {
+		packed.tickRows(stripes[i][0], stripes[i][1], world.neighborhoodMasks)
+	})
+
+	//the colors of births depend on the current colors of their neighbors, so they're all found before updating
+	births := make([][]uint32, len(stripes))
+	pool.run(len(stripes), func(i int) {
+		births[i] = world.packedBirths(stripes[i][0], stripes[i][1])
+	})
+	//the cells are updated in place, so their changes are counted for the stats as they're made
+	var counters []*statsCounter
+	if world.stats != nil {
+		counters = make([]*statsCounter, len(stripes))
+		for i := range counters {
+			counters[i] = world.stats.newCounter()
+		}
+	}
+	pool.run(len(stripes), func(i int) {
+		var counter *statsCounter
+		if counters != nil {
+			counter = counters[i]
+		}
+		world.applyPacked(stripes[i][0], stripes[i][1], births[i], counter)
+	})
+	if counters != nil {
+		world.stats.counted = world.stats.newCounter()
+		for _, counter := range counters {
+			world.stats.counted.add(counter)
+		}
+	}
+
+	packed.current, packed.next = packed.next, packed.current
+	world.tick++
+	//the data buffer isn't updated, so the regions can't be skipped on the next regular tick
+	world.allDirty = true
+	world.changedAll = true
+}
+
+func (world *World) packedBirths(minY, maxY uint32) []uint32 {
+	births := make([]uint32, 0)
+	grid := make(DataGrid, 3)
+	for i := range grid {
+		grid[i] = make([]uint32, 3)
+	}
+	world.packed.forEachCell(minY, maxY, func(y, x uint32, alive, nextAlive bool) {
+		if alive || !nextAlive {
+			return
+		}
+		if y > 0 && y < world.height-1 && x > 0 && x < world.width-1 {
+			neighborhood := world.data.InnerNeighborsValue(y, x) & world.neighborhoodMasks[y&1]
+			births = append(births, world.nextDeadState(world.data, y, x, neighborhood))
+		} else {
+			world.perimeterNeighborhoodGrid(y, x, &grid)
+			neighborhood := grid.InnerNeighborsValue(1, 1) & world.neighborhoodMasks[y&1]
+			births = append(births, world.nextDeadState(&grid, 1, 1, neighborhood))
+		}
+	})
+	return births
+}
+
+//applyPacked updates the cells that are or will be alive, counting their changes if counter isn't nil
+func (world *World) applyPacked(minY, maxY uint32, births []uint32, counter *statsCounter) {
+	world.packed.forEachCell(minY, maxY, func(y, x uint32, alive, nextAlive bool) {
+		old := (*world.data)[y][x]
+		if !alive {
+			(*world.data)[y][x] = births[0]
+			births = births[1:]
+		} else if nextAlive {
+			//the color rule doesn't blend, so survivors don't depend on their neighbors
+			(*world.data)[y][x] = world.colors.Survive(world.data, y, x, 0)
+		} else {
+			(*world.data)[y][x] = DEAD
+		}
+		if counter != nil {
+			world.stats.count(counter, old, (*world.data)[y][x])
+		}
+	})
+}
+
+//dataChanged must be called whenever the world's data is modified outside of a regular tick
+func (world *World) dataChanged() {
+	if world.packed != nil {
+		world.packed.synced = false
+	}
+	world.allDirty = true
+	world.editedAll = true
+}
+
+//cellChanged is dataChanged for when only the cell at (y, x) is modified, so that only its region and the regions
+//around it are recomputed on the next tick
+func (world *World) cellChanged(y, x uint32) {
+	if world.packed != nil {
+		world.packed.synced = false
+	}
+	if y == 0 || x == 0 || y == world.height-1 || x == world.width-1 {
+		world.edits[len(world.regions)] = true
+		return
+	}
+	for i, r := range world.regions {
+		if y >= r.minY && y < r.maxY && x >= r.minX && x < r.maxX {
+			world.edits[i] = true
+			return
+		}
+	}
+}
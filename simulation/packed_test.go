@@ -0,0 +1,115 @@
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+)
+
+//randomSoup fills the world with alive cells of the given colors (picked at random)
+func randomSoup(world *World, seed int64, colors []uint32) {
+	random := rand.New(rand.NewSource(seed))
+	for y := uint32(0); y < world.height; y++ {
+		for x := uint32(0); x < world.width; x++ {
+			if random.Intn(3) == 0 {
+				world.MarkAliveColor(y, x, colors[random.Intn(len(colors))])
+			}
+		}
+	}
+}
+
+func TestWorld_TickPackedMatchesWorld(t *testing.T) {
+	//the majority color of a birth is picked at random when there's no majority, so only Conway uses several colors,
+	//where 2 colors among 3 neighbors always have a majority
+	rules := map[string][]uint32{
+		CONWAY_RULE: {0xFF000000, 0x00FF0000},
+		"B36/S23":   {FULL},
+		"B2/S":      {FULL},
+		"B2/S013V":  {FULL},
+		"B2/S34H":   {FULL},
+	}
+	for rule, colors := range rules {
+		for topology := range TopologyNames {
+			//70 columns, so rows span 2 words with the extra cells
+			packed, err := NewWorldWithRule(50, 70, rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if packed.packed == nil {
+				t.Fatalf("expected rule %s to be packed", rule)
+			}
+			packed.SetTopology(topology)
+			packed.SetColorRule(MajorityColors{})
+			reference, _ := NewWorldWithRule(50, 70, rule)
+			reference.SetTopology(topology)
+			reference.SetColorRule(MajorityColors{})
+			reference.packed = nil
+			randomSoup(&packed, 1, colors)
+			randomSoup(&reference, 1, colors)
+
+			for i := 0; i < 100; i++ {
+				if i == 50 {
+					//edits in between ticks have to be picked up by the packed grid
+					packed.PlaceRLEAtCoords(glider, 20, 20, colors[0])
+					reference.PlaceRLEAtCoords(glider, 20, 20, colors[0])
+				}
+				packed.Tick()
+				reference.Tick()
+				for y := uint32(0); y < packed.height; y++ {
+					for x := uint32(0); x < packed.width; x++ {
+						if (*packed.data)[y][x] != (*reference.data)[y][x] {
+							t.Fatalf("%s on %s, tick %d: cell (%d, %d) is %08x, expected %08x", rule, topology, i, y, x,
+								(*packed.data)[y][x], (*reference.data)[y][x])
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNewPackedGrid(t *testing.T) {
+	for rule, expected := range map[string]bool{
+		CONWAY_RULE:                   true,
+		"B2/S013V":                    true,
+		"B2-a/S12":                    false,
+		"B2/S345/C4":                  false,
+		"R5,C0,M1,S34..58,B34..45,NM": false,
+	} {
+		world, err := NewWorldWithRule(10, 10, rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (world.packed != nil) != expected {
+			t.Errorf("expected packing for rule %s to be %v", rule, expected)
+		}
+	}
+}
+
+func BenchmarkWorld_TickPacked(b *testing.B) {
+	world := NewConwayWorld(1000, 1000)
+	world.SetColorRule(MajorityColors{})
+	randomSoup(&world, 1, []uint32{FULL})
+	//let the soup settle down, as most of the cells die in the first few hundred generations
+	for i := 0; i < 300; i++ {
+		world.Tick()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.Tick()
+	}
+}
+
+func BenchmarkWorld_TickUnpacked(b *testing.B) {
+	world := NewConwayWorld(1000, 1000)
+	world.packed = nil
+	world.SetColorRule(MajorityColors{})
+	randomSoup(&world, 1, []uint32{FULL})
+	//let the soup settle down, as most of the cells die in the first few hundred generations
+	for i := 0; i < 300; i++ {
+		world.Tick()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.Tick()
+	}
+}
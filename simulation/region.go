@@ -0,0 +1,166 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//Pattern is a rectangle of cells copied out of a world, colors, ages and states included, that can be pasted back in
+type Pattern struct {
+	height uint32
+	width  uint32
+	//indexed [y][x], with DEAD for the cells that are empty
+	cells [][]uint32
+}
+
+func (pattern Pattern) GetDims() (height uint32, width uint32) {
+	return pattern.height, pattern.width
+}
+
+//MergeMode is how the cells of a pasted pattern are combined with the cells already in the world
+type MergeMode int
+
+const (
+	//the world takes every cell of the pattern, including the empty ones
+	MERGE_OVERWRITE MergeMode = 0
+	//the world takes the cells of the pattern that aren't empty, and keeps its own cells elsewhere
+	MERGE_OR MergeMode = 1
+	//the cells of the pattern that aren't empty are placed where the world is empty, and erase the world elsewhere
+	MERGE_XOR MergeMode = 2
+	//the cells of the pattern that aren't empty erase the world, so the pattern is a mask of what to remove
+	MERGE_AND_NOT MergeMode = 3
+)
+
+var MergeModeNames = map[MergeMode]string{
+	MERGE_OVERWRITE: "overwrite",
+	MERGE_OR:        "or",
+	MERGE_XOR:       "xor",
+	MERGE_AND_NOT:   "and-not",
+}
+
+func ParseMergeMode(name string) (MergeMode, error) {
+	for mode, modeName := range MergeModeNames {
+		if strings.ToLower(name) == modeName {
+			return mode, nil
+		}
+	}
+	return MERGE_OVERWRITE, fmt.Errorf("unknown merge mode %s", name)
+}
+
+func (mode MergeMode) String() string {
+	return MergeModeNames[mode]
+}
+
+//merge returns the cell the world ends up with when the cell of a pattern is pasted over it
+func (mode MergeMode) merge(cell, pasted uint32) uint32 {
+	switch mode {
+	case MERGE_OR:
+		if pasted == DEAD {
+			return cell
+		}
+	case MERGE_XOR:
+		if pasted == DEAD {
+			return cell
+		} else if cell != DEAD {
+			return DEAD
+		}
+	case MERGE_AND_NOT:
+		if pasted == DEAD {
+			return cell
+		}
+		return DEAD
+	}
+	return pasted
+}
+
+//regionCells calls f with the coordinates in the world of every cell of the region (height by width, with its top-left
+//corner at y and x), and the cell's coordinates in the region. Regions can cross the seams of the topology, but have
+//to be entirely inside a bounded world
+func (world *World) regionCells(y, x, height, width uint32, f func(wy, wx, yy, xx uint32)) error {
+	if height == 0 || width == 0 || height > world.height || width > world.width {
+		return errors.New("the region has to fit in the world")
+	}
+	//in 64 bits, so a region near the largest coordinates doesn't wrap around into the world
+	top, left := int64(y), int64(x)
+	if world.topology == BOUNDED && (top+int64(height) > int64(world.height) || left+int64(width) > int64(world.width)) {
+		return fmt.Errorf("the %dx%d region at (%d, %d) isn't inside the world", width, height, x, y)
+	}
+	for yy := uint32(0); yy < height; yy++ {
+		for xx := uint32(0); xx < width; xx++ {
+			wy, wx, ok := world.wrapCoords(top+int64(yy), left+int64(xx))
+			if ok {
+				f(wy, wx, yy, xx)
+			}
+		}
+	}
+	return nil
+}
+
+//CopyRegion copies the cells of the region (height by width, with its top-left corner at y and x) into a pattern
+func (world *World) CopyRegion(y, x, height, width uint32) (Pattern, error) {
+	pattern := Pattern{height: height, width: width, cells: make([][]uint32, height)}
+	for i := range pattern.cells {
+		pattern.cells[i] = make([]uint32, width)
+	}
+	err := world.regionCells(y, x, height, width, func(wy, wx, yy, xx uint32) {
+		pattern.cells[yy][xx] = (*world.data)[wy][wx]
+	})
+	if err != nil {
+		return Pattern{}, err
+	}
+	return pattern, nil
+}
+
+//ClearRegion empties the cells of the region
+func (world *World) ClearRegion(y, x, height, width uint32) error {
+	err := world.regionCells(y, x, height, width, func(wy, wx, yy, xx uint32) {
+		(*world.data)[wy][wx] = DEAD
+	})
+	if err != nil {
+		return err
+	}
+	world.edited()
+	return nil
+}
+
+//CutRegion copies the cells of the region into a pattern, and empties them
+func (world *World) CutRegion(y, x, height, width uint32) (Pattern, error) {
+	pattern, err := world.CopyRegion(y, x, height, width)
+	if err != nil {
+		return Pattern{}, err
+	}
+	return pattern, world.ClearRegion(y, x, height, width)
+}
+
+//PastePattern merges the pattern into the world with its top-left corner at y and x
+func (world *World) PastePattern(pattern Pattern, y, x uint32, mode MergeMode) error {
+	if _, ok := MergeModeNames[mode]; !ok {
+		return fmt.Errorf("unknown merge mode %d", mode)
+	}
+	err := world.regionCells(y, x, pattern.height, pattern.width, func(wy, wx, yy, xx uint32) {
+		(*world.data)[wy][wx] = mode.merge((*world.data)[wy][wx], pattern.cells[yy][xx])
+	})
+	if err != nil {
+		return err
+	}
+	world.edited()
+	return nil
+}
+
+//MoveRegion moves the cells of the region so its top-left corner is at toY and toX, merging them into the cells there.
+//The region is emptied first, so the region and where it's moved to can overlap
+func (world *World) MoveRegion(y, x, height, width, toY, toX uint32, mode MergeMode) error {
+	if _, ok := MergeModeNames[mode]; !ok {
+		return fmt.Errorf("unknown merge mode %d", mode)
+	}
+	//checked first, so a move that doesn't fit doesn't cut the region
+	if err := world.regionCells(toY, toX, height, width, func(wy, wx, yy, xx uint32) {}); err != nil {
+		return err
+	}
+	pattern, err := world.CutRegion(y, x, height, width)
+	if err != nil {
+		return err
+	}
+	return world.PastePattern(pattern, toY, toX, mode)
+}
@@ -0,0 +1,140 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestParseMergeMode(t *testing.T) {
+	for mode, name := range MergeModeNames {
+		parsed, err := ParseMergeMode(name)
+		if err != nil || parsed != mode {
+			t.Errorf("expected %s to parse, got %s (%v)", name, parsed, err)
+		}
+	}
+	if _, err := ParseMergeMode("and"); err == nil {
+		t.Error("expected an error parsing an unknown merge mode")
+	}
+}
+
+func TestWorld_CopyPaste(t *testing.T) {
+	world := NewConwayWorld(20, 20)
+	world.PlaceRLEAtCoords(glider, 2, 2, 0xFF000000)
+	world.SetCell(3, 3, 0x00FF00FF)
+	pattern, err := world.CopyRegion(2, 2, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height, width := pattern.GetDims(); height != 3 || width != 3 {
+		t.Fatalf("expected a 3x3 pattern, got %dx%d", width, height)
+	}
+	if err := world.PastePattern(pattern, 10, 12, MERGE_OVERWRITE); err != nil {
+		t.Fatal(err)
+	}
+	for y := int64(0); y < 3; y++ {
+		for x := int64(0); x < 3; x++ {
+			if world.GetCell(10+y, 12+x) != world.GetCell(2+y, 2+x) {
+				t.Fatalf("expected (%d, %d) to be pasted with its color", y, x)
+			}
+		}
+	}
+
+	if _, err := world.CopyRegion(18, 18, 3, 3); err == nil {
+		t.Error("expected an error copying a region outside of a bounded world")
+	}
+	if err := world.PastePattern(pattern, 0, 18, MERGE_OR); err == nil {
+		t.Error("expected an error pasting outside of a bounded world")
+	}
+	if err := world.PastePattern(pattern, 1<<32-1, 0, MERGE_OR); err == nil {
+		t.Error("expected an error pasting where the region only fits by overflowing")
+	}
+	//on a torus, the region can cross the seams
+	world.SetTopology(TORUS)
+	if err := world.PastePattern(pattern, 19, 18, MERGE_OVERWRITE); err != nil {
+		t.Fatal(err)
+	}
+	if world.GetCell(1, 19) != world.GetCell(4, 3) || world.GetCell(0, 0) != world.GetCell(3, 4) {
+		t.Error("expected the pattern to wrap around the torus")
+	}
+	//the largest coordinates wrap around to row 15 of the torus, so the next row is 16 and not 0
+	world.SetCell(16, 18, FULL)
+	far, err := world.CopyRegion(1<<32-1, 18, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if far.cells[1][0] != FULL {
+		t.Error("expected the region to continue from the largest coordinates wrapped around the torus")
+	}
+	copied, err := world.CopyRegion(19, 18, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for y, row := range copied.cells {
+		for x, cell := range row {
+			if cell != pattern.cells[y][x] {
+				t.Fatalf("expected to copy the pattern back across the seams, got %08x at (%d, %d)", cell, y, x)
+			}
+		}
+	}
+}
+
+func TestWorld_PasteModes(t *testing.T) {
+	//the pattern is a row of a cell, an empty cell, and a cell, pasted over a row of an empty cell and 2 cells
+	pattern := Pattern{height: 1, width: 3, cells: [][]uint32{{0xFF0000FF, DEAD, 0xFF0000FF}}}
+	tests := []struct {
+		mode     MergeMode
+		expected []uint32
+	}{
+		{MERGE_OVERWRITE, []uint32{0xFF0000FF, DEAD, 0xFF0000FF}},
+		{MERGE_OR, []uint32{0xFF0000FF, 0x0000FFFF, 0xFF0000FF}},
+		{MERGE_XOR, []uint32{0xFF0000FF, 0x0000FFFF, DEAD}},
+		{MERGE_AND_NOT, []uint32{DEAD, 0x0000FFFF, DEAD}},
+	}
+	for _, test := range tests {
+		world := NewConwayWorld(5, 5)
+		world.SetCell(2, 2, 0x0000FFFF)
+		world.SetCell(2, 3, 0x0000FFFF)
+		if err := world.PastePattern(pattern, 2, 1, test.mode); err != nil {
+			t.Fatal(err)
+		}
+		for i, cell := range test.expected {
+			if world.GetCell(2, int64(1+i)) != cell {
+				t.Errorf("%s: expected cell %d to be %08x, got %08x", test.mode, i, cell, world.GetCell(2, int64(1+i)))
+			}
+		}
+	}
+}
+
+func TestWorld_CutMoveClear(t *testing.T) {
+	world := NewConwayWorld(20, 20)
+	world.PlaceRLEAtCoords(glider, 2, 2, 0xFF000000)
+	start := world.Snapshot()
+
+	pattern, err := world.CutRegion(2, 2, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(world.Snapshot().Cells) != 0 {
+		t.Fatal("expected the cut region to be empty")
+	}
+	world.PastePattern(pattern, 2, 2, MERGE_OR)
+
+	//the move overlaps the region it moves from
+	if err := world.MoveRegion(2, 2, 3, 3, 3, 4, MERGE_OVERWRITE); err != nil {
+		t.Fatal(err)
+	}
+	expected := Snapshot{Cells: make(map[[2]int64]uint32)}
+	for coords, cell := range start.Cells {
+		expected.Cells[[2]int64{coords[0] + 1, coords[1] + 2}] = cell
+	}
+	checkSnapshot(t, "move", world.Snapshot(), expected)
+	if world.MoveRegion(3, 4, 3, 3, 18, 18, MERGE_OVERWRITE) == nil || len(world.Snapshot().Cells) != 5 {
+		t.Error("expected a move outside of the world to fail without cutting the region")
+	}
+
+	if err := world.ClearRegion(0, 0, 20, 20); err != nil {
+		t.Fatal(err)
+	}
+	if len(world.Snapshot().Cells) != 0 {
+		t.Error("expected the cleared world to be empty")
+	}
+}
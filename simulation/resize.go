@@ -0,0 +1,110 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//Anchor is the part of the world that stays in place when it's resized, such as its center or its top-left corner
+type Anchor int
+
+const (
+	ANCHOR_TOP_LEFT     Anchor = 0
+	ANCHOR_TOP          Anchor = 1
+	ANCHOR_TOP_RIGHT    Anchor = 2
+	ANCHOR_LEFT         Anchor = 3
+	ANCHOR_CENTER       Anchor = 4
+	ANCHOR_RIGHT        Anchor = 5
+	ANCHOR_BOTTOM_LEFT  Anchor = 6
+	ANCHOR_BOTTOM       Anchor = 7
+	ANCHOR_BOTTOM_RIGHT Anchor = 8
+)
+
+var AnchorNames = map[Anchor]string{
+	ANCHOR_TOP_LEFT:     "top-left",
+	ANCHOR_TOP:          "top",
+	ANCHOR_TOP_RIGHT:    "top-right",
+	ANCHOR_LEFT:         "left",
+	ANCHOR_CENTER:       "center",
+	ANCHOR_RIGHT:        "right",
+	ANCHOR_BOTTOM_LEFT:  "bottom-left",
+	ANCHOR_BOTTOM:       "bottom",
+	ANCHOR_BOTTOM_RIGHT: "bottom-right",
+}
+
+func ParseAnchor(name string) (Anchor, error) {
+	for anchor, anchorName := range AnchorNames {
+		if strings.ToLower(name) == anchorName {
+			return anchor, nil
+		}
+	}
+	return ANCHOR_TOP_LEFT, fmt.Errorf("unknown anchor %s", name)
+}
+
+func (anchor Anchor) String() string {
+	return AnchorNames[anchor]
+}
+
+//offset returns how far the cells move down and right when a world of the old size is resized to the new one, keeping
+//the anchor in place
+func (anchor Anchor) offset(oldHeight, oldWidth, height, width uint32) (int64, int64) {
+	dy, dx := int64(height)-int64(oldHeight), int64(width)-int64(oldWidth)
+	//the anchors are numbered row by row, 3 to a row
+	switch anchor / 3 {
+	case 0:
+		dy = 0
+	case 1:
+		dy /= 2
+	}
+	switch anchor % 3 {
+	case 0:
+		dx = 0
+	case 1:
+		dx /= 2
+	}
+	return dy, dx
+}
+
+//Resize changes the size of the world, keeping its cells in place relative to the anchor: cells that end up outside of
+//the new size are lost, and new space is dead. The workers' partitions and the packed grid are recomputed for the new
+//size, and the history, stabilization detection and stats start over from the resized world
+func (world *World) Resize(height, width uint32, anchor Anchor) error {
+	if height == 0 || width == 0 {
+		return errors.New("the world needs a height and width of at least 1")
+	}
+	if _, ok := AnchorNames[anchor]; !ok {
+		return fmt.Errorf("unknown anchor %d", anchor)
+	}
+	if err := world.hexagonalSeams(world.topology, height); err != nil {
+		return err
+	}
+	dy, dx := anchor.offset(world.height, world.width, height, width)
+	data := make(DataGrid, height)
+	buffer := make(DataGrid, height)
+	for y := range data {
+		data[y] = make([]uint32, width)
+		buffer[y] = make([]uint32, width)
+		oldY := int64(y) - dy
+		if oldY < 0 || oldY >= int64(world.height) {
+			continue
+		}
+		for x := range data[y] {
+			if oldX := int64(x) - dx; oldX >= 0 && oldX < int64(world.width) {
+				data[y][x] = (*world.data)[oldY][oldX]
+			}
+		}
+	}
+	world.data = &data
+	world.dataBuffer = &buffer
+	world.height = height
+	world.width = width
+
+	if world.packed != nil {
+		world.packed = newPackedGrid(height, width, world.cellRules)
+	}
+	world.computePartitions()
+	world.edited()
+	world.restartTracking()
+	return nil
+}
@@ -0,0 +1,96 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestParseAnchor(t *testing.T) {
+	for anchor, name := range AnchorNames {
+		parsed, err := ParseAnchor(name)
+		if err != nil || parsed != anchor {
+			t.Errorf("expected %s to parse, got %s (%v)", name, parsed, err)
+		}
+	}
+	if _, err := ParseAnchor("middle"); err == nil {
+		t.Error("expected an error parsing an unknown anchor")
+	}
+}
+
+func TestWorld_Resize(t *testing.T) {
+	tests := []struct {
+		anchor        Anchor
+		height, width uint32
+		y, x          int64
+	}{
+		{ANCHOR_TOP_LEFT, 20, 30, 4, 4},
+		{ANCHOR_CENTER, 20, 30, 9, 14},
+		{ANCHOR_BOTTOM_RIGHT, 20, 30, 14, 24},
+		{ANCHOR_RIGHT, 20, 30, 9, 24},
+		{ANCHOR_CENTER, 6, 8, 2, 3},
+		{ANCHOR_BOTTOM, 7, 10, 1, 4},
+	}
+	for _, test := range tests {
+		world := NewConwayWorld(10, 10)
+		world.SetColorRule(MajorityColors{})
+		world.PlaceRLEAtCoords(glider, 4, 4, 0xFF000000)
+		start := world.Snapshot()
+		if err := world.Resize(test.height, test.width, test.anchor); err != nil {
+			t.Fatal(err)
+		}
+		if height, width := world.GetDims(); height != test.height || width != test.width {
+			t.Fatalf("expected the world to be %dx%d, got %dx%d", test.width, test.height, width, height)
+		}
+		expected := Snapshot{Cells: make(map[[2]int64]uint32)}
+		for coords, cell := range start.Cells {
+			expected.Cells[[2]int64{coords[0] - 4 + test.y, coords[1] - 4 + test.x}] = cell
+		}
+		checkSnapshot(t, test.anchor.String(), world.Snapshot(), expected)
+
+		//the resized world runs like a world created at that size
+		fresh := NewConwayWorld(test.height, test.width)
+		fresh.SetColorRule(MajorityColors{})
+		fresh.PlaceRLEAtCoords(glider, uint32(test.y), uint32(test.x), 0xFF000000)
+		if (world.packed == nil) != (fresh.packed == nil) {
+			t.Fatalf("%s: expected the resized world to be packed like a new one", test.anchor)
+		}
+		for i := 0; i < 12; i++ {
+			world.Tick()
+			fresh.Tick()
+		}
+		checkData(t, &world, copyData(&fresh))
+	}
+}
+
+func TestWorld_ResizeResets(t *testing.T) {
+	world := NewConwayWorld(20, 20)
+	world.PlaceRLEAtCoords(glider, 2, 2, FULL)
+	if err := world.EnableHistory(50, 5); err != nil {
+		t.Fatal(err)
+	}
+	world.EnableStats(50)
+	world.SetStatsColors([]uint32{FULL})
+	world.EnableStabilizationDetection(10)
+	for i := 0; i < 10; i++ {
+		world.Tick()
+	}
+	if err := world.Resize(40, 40, ANCHOR_TOP_LEFT); err != nil {
+		t.Fatal(err)
+	}
+	if oldest, newest, ok := world.GetHistoryRange(); !ok || oldest != 10 || newest != 10 {
+		t.Errorf("expected the history to start over at tick 10, got %d to %d", oldest, newest)
+	}
+	if len(world.GetStats()) != 0 {
+		t.Error("expected the stats to start over")
+	}
+	world.Tick()
+	if stats, ok := world.GetLatestStats(); !ok || stats.Population != 5 || len(stats.Colors) != 1 {
+		t.Errorf("expected the stats to count the glider by the same colors, got %+v", stats)
+	}
+	if !world.StepBack() || world.GetTick() != 10 || world.StepBack() {
+		t.Error("expected to step back to the resize, but not before it")
+	}
+
+	if world.Resize(0, 10, ANCHOR_CENTER) == nil || world.Resize(10, 10, Anchor(9)) == nil {
+		t.Error("expected an error resizing to an empty world or with an unknown anchor")
+	}
+}
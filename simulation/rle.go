@@ -18,6 +18,9 @@ type RLE struct {
 	height uint32
 
 	data [][]bool
+	//only set for multi-state RLEs, whose cells are in state . (0), A (1), B (2) and so on, with data set for the cells
+	//that aren't in state 0
+	states [][]uint8
 }
 
 func LoadRLE(path string) (RLE, error) {
@@ -54,10 +57,42 @@ func LoadRLE(path string) (RLE, error) {
 	}
 	x := 0
 	y := 0
+	//the p to y prefix of a multi-state letter, for states 25 and up
+	prefix := 0
 	for _, line := range lines[offset:] {
 		buf := bytes.Buffer{}
 		for _, c := range line {
-			if c == '$' {
+			if c == '.' || (c >= 'A' && c <= 'X') {
+				length := 1
+				if buf.Len() > 0 {
+					length, err = strconv.Atoi(buf.String())
+					if err != nil {
+						return rle, err
+					}
+				}
+				state := 0
+				if c != '.' {
+					state = prefix*24 + int(c-'A') + 1
+				}
+				if state > 255 {
+					return rle, fmt.Errorf("state %d is out of range", state)
+				}
+				if rle.states == nil {
+					rle.states = make([][]uint8, rle.height)
+					for i := range rle.states {
+						rle.states[i] = make([]uint8, rle.width)
+					}
+				}
+				for i := 0; i < length; i++ {
+					rle.states[y][x] = uint8(state)
+					rle.data[y][x] = state != 0
+					x++
+				}
+				prefix = 0
+				buf = bytes.Buffer{}
+			} else if c >= 'p' && c <= 'y' {
+				prefix = int(c-'p') + 1
+			} else if c == '$' {
 				if buf.Len() > 0 {
 					length, err := strconv.Atoi(buf.String())
 					if err != nil {
@@ -148,7 +183,10 @@ func (rle RLE) toProto() *message.RLE {
 	idx := 0
 	for _, row := range rle.data {
 		for _, cell := range row {
-			if cell {
+			if cell && rle.states != nil {
+				//multi-state RLEs send the states of their cells
+				data[idx] = rle.states[idx/int(rle.width)][idx%int(rle.width)]
+			} else if cell {
 				data[idx] = 0xFF
 			} else {
 				data[idx] = 0x00
@@ -0,0 +1,289 @@
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const CONWAY_RULE = "B3/S23"
+
+//Rule describes a Life-like rule as the neighborhoods that cause a birth or a survival. Neighborhoods are indexed by
+//their 0b_W_SW_S_SE_E_NE_N_NW value, which covers both outer totalistic rules and isotropic non-totalistic rules.
+//Generations rules have more than 2 States, where cells that fail to survive pass through States-2 refractory states
+//before dying. Von Neumann and hexagonal rules only ever see the neighbors of their Neighborhood
+type Rule struct {
+	Birth        [256]bool
+	Survive      [256]bool
+	States       uint32
+	Neighborhood NeighborhoodType
+}
+
+//ParseRule accepts rulestrings in either B/S notation ("B36/S23") or S/B notation ("23/36"). Conditions may use
+//Hensel notation for isotropic non-totalistic rules, as in "B2-a/S12" or "B2ce3aiy/S23-a4i". Generations rules add
+//the number of states, as in "B2/S/C3" or "345/2/4". A "V" or "H" suffix selects the von Neumann or hexagonal
+//neighborhood, as in "B2/S013V" or "B2/S34H"
+func ParseRule(rulestring string) (Rule, error) {
+	rule := Rule{States: 2, Neighborhood: MOORE}
+	str := strings.ToLower(strings.ReplaceAll(rulestring, " ", ""))
+	if str == "" {
+		return rule, errors.New("empty rulestring")
+	}
+
+	if strings.HasSuffix(str, "v") {
+		rule.Neighborhood = VON_NEUMANN
+		str = str[:len(str)-1]
+	} else if strings.HasSuffix(str, "h") {
+		rule.Neighborhood = HEXAGONAL
+		str = str[:len(str)-1]
+	}
+	if str == "" {
+		return rule, errors.New("empty rulestring")
+	}
+
+	//Generations rules end in a third part with the number of states
+	if strings.Count(str, "/") == 2 {
+		idx := strings.LastIndex(str, "/")
+		states, err := strconv.ParseUint(strings.TrimPrefix(str[idx+1:], "c"), 10, 32)
+		if err != nil {
+			return rule, fmt.Errorf("invalid number of states in rulestring %s", rulestring)
+		}
+		if states < 2 || states > MAX_STATES {
+			return rule, fmt.Errorf("number of states must be between 2 and %d", MAX_STATES)
+		}
+		rule.States = uint32(states)
+		str = str[:idx]
+	}
+
+	if str[0] == 'b' || str[0] == 's' {
+		//B/S notation; the slash is optional, as in "B3S23"
+		parts := strings.Split(str, "/")
+		if len(parts) == 1 {
+			idx := strings.IndexAny(str[1:], "bs")
+			if idx > -1 {
+				parts = []string{str[:idx+1], str[idx+1:]}
+			}
+		}
+		if len(parts) != 2 {
+			return rule, fmt.Errorf("invalid rulestring %s", rulestring)
+		}
+		seenB, seenS := false, false
+		for _, part := range parts {
+			if part == "" {
+				return rule, fmt.Errorf("invalid rulestring %s", rulestring)
+			}
+			var err error
+			switch part[0] {
+			case 'b':
+				if seenB {
+					return rule, fmt.Errorf("duplicate birth conditions in rulestring %s", rulestring)
+				}
+				seenB = true
+				err = parseConditions(part[1:], rule.Neighborhood, &rule.Birth)
+			case 's':
+				if seenS {
+					return rule, fmt.Errorf("duplicate survival conditions in rulestring %s", rulestring)
+				}
+				seenS = true
+				err = parseConditions(part[1:], rule.Neighborhood, &rule.Survive)
+			default:
+				return rule, fmt.Errorf("invalid rulestring %s", rulestring)
+			}
+			if err != nil {
+				return rule, err
+			}
+		}
+		return rule, nil
+	}
+
+	//S/B notation, as in "23/3"
+	parts := strings.Split(str, "/")
+	if len(parts) != 2 {
+		return rule, fmt.Errorf("invalid rulestring %s", rulestring)
+	}
+	err := parseConditions(parts[0], rule.Neighborhood, &rule.Survive)
+	if err != nil {
+		return rule, err
+	}
+	err = parseConditions(parts[1], rule.Neighborhood, &rule.Birth)
+	if err != nil {
+		return rule, err
+	}
+	return rule, nil
+}
+
+//parses a list of neighbor counts, each optionally followed by Hensel letters (or a '-' and the letters to exclude).
+//Hensel letters are only valid for the Moore neighborhood
+func parseConditions(conditions string, neighborhood NeighborhoodType, table *[256]bool) error {
+	i := 0
+	for i < len(conditions) {
+		c := conditions[i]
+		if c < '0' || int(c-'0') > neighborhood.MaxNeighbors() {
+			return fmt.Errorf("invalid neighbor count %c", c)
+		}
+		count := int(c - '0')
+		i++
+		if neighborhood != MOORE && i < len(conditions) && (conditions[i] < '0' || conditions[i] > '9') {
+			return fmt.Errorf("Hensel notation is only supported for the Moore neighborhood")
+		}
+
+		negate := false
+		if i < len(conditions) && conditions[i] == '-' {
+			negate = true
+			i++
+		}
+		letters := ""
+		for i < len(conditions) && conditions[i] >= 'a' && conditions[i] <= 'z' {
+			if strings.IndexByte(HenselLetters[count], conditions[i]) < 0 {
+				return fmt.Errorf("invalid Hensel letter %c for count %d", conditions[i], count)
+			}
+			letters += string(conditions[i])
+			i++
+		}
+		if negate && letters == "" {
+			return fmt.Errorf("expected Hensel letters after %d-", count)
+		}
+
+		for n := 0; n < 256; n++ {
+			nCount, letter := HenselClass(byte(n))
+			if nCount != count {
+				continue
+			}
+			if letters == "" || (strings.IndexByte(letters, letter) > -1) != negate {
+				table[n] = true
+			}
+		}
+	}
+	return nil
+}
+
+//String returns the canonical B/S form of the rule, for example "B36/S23", "B2-a/S12" or "B2/S/C3"
+func (rule Rule) String() string {
+	buf := bytes.NewBufferString("B")
+	writeConditions(buf, &rule.Birth)
+	buf.WriteString("/S")
+	writeConditions(buf, &rule.Survive)
+	if rule.States > 2 {
+		buf.WriteString(fmt.Sprintf("/C%d", rule.States))
+	}
+	if rule.Neighborhood == VON_NEUMANN {
+		buf.WriteString("V")
+	} else if rule.Neighborhood == HEXAGONAL {
+		buf.WriteString("H")
+	}
+	return buf.String()
+}
+
+func writeConditions(buf *bytes.Buffer, table *[256]bool) {
+	present := [9]map[byte]bool{}
+	for n := 0; n < 256; n++ {
+		if table[n] {
+			count, letter := HenselClass(byte(n))
+			if present[count] == nil {
+				present[count] = make(map[byte]bool)
+			}
+			present[count][letter] = true
+		}
+	}
+	for count, letters := range present {
+		if len(letters) == 0 {
+			continue
+		}
+		buf.WriteByte(byte('0' + count))
+		all := HenselLetters[count]
+		if len(letters) == len(all) || all == "" {
+			continue
+		}
+		included := ""
+		excluded := ""
+		for _, letter := range []byte(all) {
+			if letters[letter] {
+				included += string(letter)
+			} else {
+				excluded += string(letter)
+			}
+		}
+		if len(included) <= len(excluded) {
+			buf.WriteString(included)
+		} else {
+			buf.WriteString("-" + excluded)
+		}
+	}
+}
+
+//IsTotalistic is true if the rule only depends on the number of neighbors, and not on their arrangement
+func (rule Rule) IsTotalistic() bool {
+	for n := 0; n < 256; n++ {
+		count := NumNeighbors(byte(n))
+		//the neighborhood with the first (lowest) n bits set is a representative of every count
+		first := byte(1<<count - 1)
+		if rule.Birth[n] != rule.Birth[first] || rule.Survive[n] != rule.Survive[first] {
+			return false
+		}
+	}
+	return true
+}
+
+//Returns a mapping of the neighbor value to the output, in the same form as GenerateConwayNeighborsRules
+func (rule Rule) GenerateNeighborsRules() (alive map[byte]bool, dead map[byte]bool) {
+	alive = make(map[byte]bool, 256)
+	dead = make(map[byte]bool, 256)
+
+	for n := 0; n < 256; n++ {
+		alive[byte(n)] = rule.Survive[n]
+		dead[byte(n)] = rule.Birth[n]
+	}
+	return alive, dead
+}
+
+//cellRules holds everything needed to compute the next state of a cell from its neighborhood
+type cellRules struct {
+	aliveRulesMapping map[byte]bool
+	deadRulesMapping  map[byte]bool
+	states            uint32
+	//the neighbors that are part of the neighborhood, for even and odd rows
+	neighborhoodMasks [2]byte
+	//how the colors of the cells change as they're born and survive
+	colors ColorRule
+	//only set for named multi-state rules like Wireworld, which use it instead of the neighbor mappings and colors
+	stateRule StateRule
+}
+
+func newCellRules(rule Rule) cellRules {
+	alive, dead := rule.GenerateNeighborsRules()
+	return cellRules{
+		aliveRulesMapping: alive,
+		deadRulesMapping:  dead,
+		states:            rule.States,
+		neighborhoodMasks: rule.Neighborhood.NeighborhoodMasks(),
+		colors:            RGBBlend{},
+	}
+}
+
+//nextAliveState returns the next state of the alive cell at (y, x) of src
+func (rules *cellRules) nextAliveState(src *DataGrid, y, x uint32, neighborhood byte) uint32 {
+	if rules.aliveRulesMapping[neighborhood] {
+		cell := rules.colors.Survive(src, y, x, neighborhood)
+		if rules.states > 2 {
+			//the lower byte holds the state for Generations rules, so alive cells don't age
+			cell |= ALIVE_NEW
+		}
+		return cell
+	} else if rules.states > 2 {
+		return rules.nextRefractoryState((*src)[y][x])
+	}
+	return DEAD
+}
+
+//nextDeadState returns the next state of the cell at (y, x) of src that isn't alive
+func (rules *cellRules) nextDeadState(src *DataGrid, y, x uint32, neighborhood byte) uint32 {
+	if (*src)[y][x] != DEAD {
+		//refractory cells can't be born into
+		return rules.nextRefractoryState((*src)[y][x])
+	} else if rules.deadRulesMapping[neighborhood] {
+		return rules.colors.Born(src, y, x, neighborhood)
+	}
+	return DEAD
+}
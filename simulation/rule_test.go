@@ -0,0 +1,120 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestParseRule(t *testing.T) {
+	rules := map[string]string{
+		"B3/S23":             "B3/S23",
+		"b36/s23":            "B36/S23",
+		"23/36":              "B36/S23",
+		"B3S23":              "B3/S23",
+		"S23/B3":             "B3/S23",
+		"B2/S":               "B2/S",
+		"/2":                 "B2/S",
+		"B3678/S34678":       "B3678/S34678",
+		"B3/S012345678":      "B3/S012345678",
+		"B2-a/S12":           "B2-a/S12",
+		"b2cekin/s12":        "B2-a/S12",
+		"B3/S2-a3":           "B3/S2-a3",
+		"B2ce3aiy/S23":       "B2ce3aiy/S23",
+		"B3ceaiknjqry/S23":   "B3/S23",
+		"B4-ceaiknjqrtwyz/S": "B/S",
+		"B3/S23/C2":          "B3/S23",
+		"B2/S/C3":            "B2/S/C3",
+		"/2/3":               "B2/S/C3",
+		"345/2/4":            "B2/S345/C4",
+		"B2-a/S12/25":        "B2-a/S12/C25",
+		"B2/S013V":           "B2/S013V",
+		"34/2H":              "B2/S34H",
+		"B2/S34/C3H":         "B2/S34/C3H",
+	}
+	for str, expected := range rules {
+		rule, err := ParseRule(str)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %s", str, err)
+		} else if rule.String() != expected {
+			t.Errorf("Parsed %s as %s, expected %s", str, rule.String(), expected)
+		}
+	}
+
+	for _, str := range []string{"", "B9/S23", "B3/S23/C1", "B3/S23/C129", "B2/S/C", "X3/S23", "B3/B4", "B1a/S23", "B2-/S23", "B5/S23V", "B7/S23H", "B2a/S23V", "V"} {
+		_, err := ParseRule(str)
+		if err == nil {
+			t.Errorf("Expected an error parsing %s", str)
+		}
+	}
+}
+
+func TestRule_IsTotalistic(t *testing.T) {
+	rule, _ := ParseRule("B36/S23")
+	if !rule.IsTotalistic() {
+		t.Fail()
+	}
+	rule, _ = ParseRule("B2-a/S12")
+	if rule.IsTotalistic() {
+		t.Fail()
+	}
+}
+
+func TestNewWorldWithRule(t *testing.T) {
+	//Seeds: every cell dies, and cells with exactly 2 neighbors are born
+	world, err := NewWorldWithRule(10, 10, "B2/S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if world.GetRule() != "B2/S" {
+		t.Fail()
+	}
+	world.MarkAlive(4, 4)
+	world.MarkAlive(4, 5)
+	world.Tick()
+
+	if (*world.data)[4][4]&ALIVE_BIT > 0 || (*world.data)[4][5]&ALIVE_BIT > 0 {
+		t.Fail()
+	}
+	for _, x := range []uint32{4, 5} {
+		if (*world.data)[3][x]&ALIVE_BIT == 0 || (*world.data)[5][x]&ALIVE_BIT == 0 {
+			t.Fail()
+		}
+	}
+}
+
+func TestNewWorldWithRuleNeighborhoods(t *testing.T) {
+	//every neighbor of a single cell is born with B1, so the births show the shape of the neighborhood
+	expected := map[string][][2]uint32{
+		"B1/SV": {{3, 4}, {4, 3}, {4, 5}, {5, 4}},
+		//(4, 4) is on an even row, so the rows above and below are offset to the right
+		"B1/SH": {{3, 3}, {3, 4}, {4, 3}, {4, 5}, {5, 3}, {5, 4}},
+	}
+	for rulestring, cells := range expected {
+		for _, colors := range []ColorRule{RGBBlend{}, MajorityColors{}} {
+			world, err := NewWorldWithRule(10, 10, rulestring)
+			if err != nil {
+				t.Fatal(err)
+			}
+			world.SetColorRule(colors)
+			world.MarkAlive(4, 4)
+			world.Tick()
+			alive := aliveCells(&world)
+			if len(alive) != len(cells) {
+				t.Errorf("%s: expected %d cells, got %d", rulestring, len(cells), len(alive))
+			}
+			for _, cell := range cells {
+				if !alive[cell] {
+					t.Errorf("%s: expected %v to be born", rulestring, cell)
+				}
+			}
+		}
+	}
+
+	//on an odd row, the hexagonal neighborhood leans the other way
+	world, _ := NewWorldWithRule(10, 10, "B1/SH")
+	world.MarkAlive(5, 4)
+	world.Tick()
+	alive := aliveCells(&world)
+	if len(alive) != 6 || !alive[[2]uint32{4, 5}] || !alive[[2]uint32{6, 5}] || alive[[2]uint32{4, 3}] {
+		t.Errorf("Unexpected hexagonal neighborhood on an odd row: %v", alive)
+	}
+}
@@ -0,0 +1,182 @@
+package simulation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+//MAX_SOUP_SIZE is the most cells a soup can have, so a request for a soup can't use up the memory of the server before
+//it's placed, which matters for sparse worlds that have no size to check the soup against
+const MAX_SOUP_SIZE = 1 << 22
+
+type Symmetry int
+
+const (
+	//no symmetry
+	C1 Symmetry = 0
+	//unchanged by a half turn
+	C2 Symmetry = 1
+	//unchanged by a quarter turn; the soup must be square
+	C4 Symmetry = 2
+	//mirrored left to right
+	D2 Symmetry = 3
+	//mirrored left to right, and top to bottom
+	D4 Symmetry = 4
+	//unchanged by any rotation or reflection of the square; the soup must be square
+	D8 Symmetry = 5
+)
+
+var SymmetryNames = map[Symmetry]string{
+	C1: "C1",
+	C2: "C2",
+	C4: "C4",
+	D2: "D2",
+	D4: "D4",
+	D8: "D8",
+}
+
+func ParseSymmetry(name string) (Symmetry, error) {
+	for symmetry, symmetryName := range SymmetryNames {
+		if strings.ToUpper(name) == symmetryName {
+			return symmetry, nil
+		}
+	}
+	return C1, fmt.Errorf("unknown symmetry %s", name)
+}
+
+func (symmetry Symmetry) String() string {
+	return SymmetryNames[symmetry]
+}
+
+//images returns the cells that the symmetry maps (y, x) to in a soup of the given size, including (y, x) itself
+func (symmetry Symmetry) images(y, x, height, width uint32) [][2]uint32 {
+	flipY, flipX := height-1-y, width-1-x
+	switch symmetry {
+	case C2:
+		return [][2]uint32{{y, x}, {flipY, flipX}}
+	case C4:
+		return [][2]uint32{{y, x}, {x, flipY}, {flipY, flipX}, {flipX, y}}
+	case D2:
+		return [][2]uint32{{y, x}, {y, flipX}}
+	case D4:
+		return [][2]uint32{{y, x}, {y, flipX}, {flipY, x}, {flipY, flipX}}
+	case D8:
+		return [][2]uint32{{y, x}, {x, flipY}, {flipY, flipX}, {flipX, y}, {y, flipX}, {flipY, x}, {x, y}, {flipX, flipY}}
+	default:
+		return [][2]uint32{{y, x}}
+	}
+}
+
+//soupRandom is a stream of random numbers that only depends on the seed: the SHA-256 hashes of the seed followed by
+//a counter, so soups can be reproduced anywhere from their seed
+type soupRandom struct {
+	seed    string
+	counter uint64
+	buf     []byte
+}
+
+func (random *soupRandom) next() uint32 {
+	if len(random.buf) < 4 {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", random.seed, random.counter)))
+		random.counter++
+		random.buf = sum[:]
+	}
+	value := binary.BigEndian.Uint32(random.buf)
+	random.buf = random.buf[4:]
+	return value
+}
+
+//GenerateSoup creates a random soup where each cell is alive with the given probability, and the soup has the given
+//symmetry. The same seed always creates the same soup
+func GenerateSoup(seed string, height, width uint32, density float64, symmetry Symmetry) (RLE, error) {
+	if height == 0 || width == 0 {
+		return RLE{}, fmt.Errorf("the soup must be at least 1x1")
+	}
+	if uint64(height)*uint64(width) > MAX_SOUP_SIZE {
+		return RLE{}, fmt.Errorf("the soup can't have more than %d cells", MAX_SOUP_SIZE)
+	}
+	if math.IsNaN(density) || density < 0 || density > 1 {
+		return RLE{}, fmt.Errorf("density must be between 0 and 1")
+	}
+	if (symmetry == C4 || symmetry == D8) && height != width {
+		return RLE{}, fmt.Errorf("%s soups must be square", symmetry)
+	}
+	if _, ok := SymmetryNames[symmetry]; !ok {
+		return RLE{}, fmt.Errorf("unknown symmetry %d", symmetry)
+	}
+
+	rle := RLE{
+		name:   fmt.Sprintf("%s soup %s", symmetry, seed),
+		width:  width,
+		height: height,
+		data:   make([][]bool, height),
+	}
+	for y := range rle.data {
+		rle.data[y] = make([]bool, width)
+	}
+	random := soupRandom{seed: seed}
+	threshold := uint64(density * (1 << 32))
+	for y := uint32(0); y < height; y++ {
+		for x := uint32(0); x < width; x++ {
+			//every cell takes the value of the first of its images, which has already been decided unless it's this one
+			first := [2]uint32{y, x}
+			for _, image := range symmetry.images(y, x, height, width) {
+				if image[0] < first[0] || (image[0] == first[0] && image[1] < first[1]) {
+					first = image
+				}
+			}
+			if first == [2]uint32{y, x} {
+				rle.data[y][x] = uint64(random.next()) < threshold
+			} else {
+				rle.data[y][x] = rle.data[first[0]][first[1]]
+			}
+		}
+	}
+	return rle, nil
+}
+
+//RandomizeRegion replaces the region with its top-left corner at (y, x) with a soup, as generated by GenerateSoup. The
+//region follows the same rules as PlaceRLEAtCoords
+func (world *World) RandomizeRegion(y, x, height, width uint32, seed string, density float64, symmetry Symmetry,
+	color uint32) error {
+	//checked before the soup is generated, as its size comes from the client. A region bigger than a wrapping world
+	//would overlap itself
+	if height > world.height || width > world.width || (world.topology == BOUNDED &&
+		(uint64(y)+uint64(height) > uint64(world.height) || uint64(x)+uint64(width) > uint64(world.width))) {
+		return fmt.Errorf("the region doesn't fit in the world")
+	}
+	soup, err := GenerateSoup(seed, height, width, density, symmetry)
+	if err != nil {
+		return err
+	}
+	for yy := uint32(0); yy < height; yy++ {
+		for xx := uint32(0); xx < width; xx++ {
+			wy, wx, ok := world.wrapCoords(int64(y)+int64(yy), int64(x)+int64(xx))
+			if ok {
+				(*world.data)[wy][wx] = DEAD
+			}
+		}
+	}
+	world.edited()
+	world.PlaceRLEAtCoords(soup, y, x, color)
+	return nil
+}
+
+//RandomizeRegion replaces the region with its top-left corner at (y, x) with a soup, as generated by GenerateSoup
+func (world *SparseWorld) RandomizeRegion(y, x int64, height, width uint32, seed string, density float64,
+	symmetry Symmetry, color uint32) error {
+	soup, err := GenerateSoup(seed, height, width, density, symmetry)
+	if err != nil {
+		return err
+	}
+	for yy := int64(0); yy < int64(height); yy++ {
+		for xx := int64(0); xx < int64(width); xx++ {
+			world.SetCell(y+yy, x+xx, DEAD)
+		}
+	}
+	world.PlaceRLEAtCoords(soup, y, x, color)
+	return nil
+}
@@ -0,0 +1,120 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGenerateSoup(t *testing.T) {
+	for symmetry := range SymmetryNames {
+		soup, err := GenerateSoup("golife", 16, 16, 0.5, symmetry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		same, _ := GenerateSoup("golife", 16, 16, 0.5, symmetry)
+		other, _ := GenerateSoup("golife2", 16, 16, 0.5, symmetry)
+		differs := false
+		alive := 0
+		for y := uint32(0); y < 16; y++ {
+			for x := uint32(0); x < 16; x++ {
+				if soup.data[y][x] != same.data[y][x] {
+					t.Fatalf("%s: the same seed gave different soups", symmetry)
+				}
+				differs = differs || soup.data[y][x] != other.data[y][x]
+				for _, image := range symmetry.images(y, x, 16, 16) {
+					if soup.data[y][x] != soup.data[image[0]][image[1]] {
+						t.Fatalf("%s: cell (%d, %d) doesn't match its image %v", symmetry, y, x, image)
+					}
+				}
+				if soup.data[y][x] {
+					alive++
+				}
+			}
+		}
+		if !differs {
+			t.Errorf("%s: different seeds gave the same soup", symmetry)
+		}
+		if alive < 64 || alive > 192 {
+			t.Errorf("%s: %d of 256 cells are alive at a density of 0.5", symmetry, alive)
+		}
+	}
+
+	//the soup only depends on the seed, so this can't change without breaking shared seeds
+	soup, _ := GenerateSoup("golife", 4, 4, 0.5, C1)
+	if soup.ToString() != reproducibleSoup {
+		t.Errorf("expected the soup\n%s, got\n%s", reproducibleSoup, soup.ToString())
+	}
+
+	for _, size := range [][2]uint32{{0, 4}, {4, 5}} {
+		if _, err := GenerateSoup("golife", size[0], size[1], 0.5, D8); err == nil {
+			t.Errorf("expected an error for a %dx%d D8 soup", size[0], size[1])
+		}
+	}
+	if _, err := GenerateSoup("golife", 4, 4, 1.5, C1); err == nil {
+		t.Error("expected an error for a density over 1")
+	}
+	if _, err := GenerateSoup("golife", 4, 4, math.NaN(), C1); err == nil {
+		t.Error("expected an error for a density of NaN")
+	}
+	if _, err := ParseSymmetry("c4"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWorld_RandomizeRegion(t *testing.T) {
+	world := NewConwayWorld(20, 20)
+	for y := uint32(0); y < 20; y++ {
+		for x := uint32(0); x < 20; x++ {
+			world.MarkAlive(y, x)
+		}
+	}
+	err := world.RandomizeRegion(5, 6, 8, 8, "golife", 0.3, D4, 0xFF000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	soup, _ := GenerateSoup("golife", 8, 8, 0.3, D4)
+	for y := uint32(0); y < 20; y++ {
+		for x := uint32(0); x < 20; x++ {
+			expected := FULL
+			if y >= 5 && y < 13 && x >= 6 && x < 14 {
+				expected = DEAD
+				if soup.data[y-5][x-6] {
+					expected = 0xFF000000 | ALIVE_NEW
+				}
+			}
+			if (*world.data)[y][x] != expected {
+				t.Fatalf("cell (%d, %d) is %08x, expected %08x", y, x, (*world.data)[y][x], expected)
+			}
+		}
+	}
+	if err := world.RandomizeRegion(15, 15, 8, 8, "golife", 0.3, C1, FULL); err == nil {
+		t.Error("expected an error for a region that doesn't fit")
+	}
+	//y+height doesn't overflow into the world, and a wrapping world still can't hold a soup bigger than itself
+	if err := world.RandomizeRegion(1<<32-4, 0, 8, 8, "golife", 0.3, C1, FULL); err == nil {
+		t.Error("expected an error for a region that only fits by overflowing")
+	}
+	world.SetTopology(TORUS)
+	if err := world.RandomizeRegion(0, 0, 1<<31, 1<<31, "golife", 0.3, C1, FULL); err == nil {
+		t.Error("expected an error for a region bigger than the torus")
+	}
+
+	sparse, _ := NewSparseWorld(CONWAY_RULE)
+	if err := sparse.RandomizeRegion(0, 0, 1<<16, 1<<16, "golife", 0.3, C1, FULL); err == nil {
+		t.Errorf("expected an error for a soup of more than %d cells", MAX_SOUP_SIZE)
+	}
+	sparse.RandomizeRegion(-4, -4, 8, 8, "golife", 0.3, D4, 0xFF000000)
+	for y := int64(0); y < 8; y++ {
+		for x := int64(0); x < 8; x++ {
+			if isAliveBool(sparse.GetCell(y-4, x-4)) != soup.data[y][x] {
+				t.Fatalf("cell (%d, %d) of the sparse world doesn't match the soup", y-4, x-4)
+			}
+		}
+	}
+}
+
+const reproducibleSoup = "Name: C1 soup golife, Height: 4, Width: 4\n" +
+	" X  _  _  X \n" +
+	" X  X  _  _ \n" +
+	" X  X  X  X \n" +
+	" _  _  X  X \n"
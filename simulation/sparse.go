@@ -0,0 +1,390 @@
+package simulation
+
+import (
+	"errors"
+	"github.com/denverquane/golife/proto/message"
+	"sort"
+)
+
+const CHUNK_SIZE = 64
+
+//MAX_FRAME_SIZE is the largest height and width of the part of the world sent to clients every tick, as the bounding
+//box of the world grows without bound once something like a glider escapes
+const MAX_FRAME_SIZE = 1024
+
+type chunkCoords struct {
+	y int64
+	x int64
+}
+
+//indexed [y][x], like the DataGrid
+type chunk [CHUNK_SIZE][CHUNK_SIZE]uint32
+
+//SparseWorld is an unbounded world, which only stores the chunks that contain cells that aren't dead. Chunks are
+//allocated as patterns grow into them, and freed once everything in them dies
+type SparseWorld struct {
+	cellRules
+	rule   string
+	chunks map[chunkCoords]*chunk
+	tick   uint64
+	//the workers are started on the first tick, and share out the active chunks
+	workers uint32
+	pool    *workerPool
+}
+
+//NewSparseWorld accepts the same rulestrings as NewWorldWithRule, except for Larger than Life rules, named rules and
+//rules with B0, which would fill the infinite plane in a single tick
+func NewSparseWorld(rulestring string) (*SparseWorld, error) {
+	if IsLtLRule(rulestring) {
+		return nil, errors.New("Larger than Life rules aren't supported on unbounded worlds")
+	}
+	if _, ok := ParseNamedRule(rulestring); ok {
+		return nil, errors.New("named rules aren't supported on unbounded worlds")
+	}
+	if IsMargolusRule(rulestring) || IsElementaryRule(rulestring) {
+		return nil, errors.New("block and elementary rules aren't supported on unbounded worlds")
+	}
+	rule, err := ParseRule(rulestring)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Birth[0] {
+		return nil, errors.New("rules with B0 aren't supported on unbounded worlds")
+	}
+	return &SparseWorld{
+		cellRules: newCellRules(rule),
+		rule:      rule.String(),
+		chunks:    make(map[chunkCoords]*chunk),
+		workers:   defaultWorkers(),
+	}, nil
+}
+
+func (world *SparseWorld) GetTick() uint64 {
+	return world.tick
+}
+
+func (world *SparseWorld) GetRule() string {
+	return world.rule
+}
+
+func (world *SparseWorld) GetStates() uint32 {
+	return world.states
+}
+
+//GetChunkCount returns the number of chunks currently allocated
+func (world *SparseWorld) GetChunkCount() int {
+	return len(world.chunks)
+}
+
+//splits world coordinates into the coordinates of their chunk, and the offset within it
+func chunkOf(y, x int64) (chunkCoords, int64, int64) {
+	coords := chunkCoords{y: floorDiv(y, CHUNK_SIZE), x: floorDiv(x, CHUNK_SIZE)}
+	return coords, y - coords.y*CHUNK_SIZE, x - coords.x*CHUNK_SIZE
+}
+
+func (world *SparseWorld) GetCell(y, x int64) uint32 {
+	coords, cy, cx := chunkOf(y, x)
+	c, ok := world.chunks[coords]
+	if !ok {
+		return DEAD
+	}
+	return c[cy][cx]
+}
+
+func (world *SparseWorld) SetCell(y, x int64, cell uint32) {
+	coords, cy, cx := chunkOf(y, x)
+	c, ok := world.chunks[coords]
+	if !ok {
+		if cell == DEAD {
+			return
+		}
+		c = &chunk{}
+		world.chunks[coords] = c
+	}
+	c[cy][cx] = cell
+}
+
+func (world *SparseWorld) MarkAlive(y, x int64) {
+	world.SetCell(y, x, FULL)
+}
+
+func (world *SparseWorld) MarkAliveColor(y, x int64, color uint32) {
+	world.SetCell(y, x, color|ALIVE_NEW)
+}
+
+//PlaceRLEAtCoords places the RLE with its top-left corner at (y, x). There are no edges, so it always fits
+func (world *SparseWorld) PlaceRLEAtCoords(rle RLE, y, x int64, color uint32) bool {
+	for yy := uint32(0); yy < rle.height; yy++ {
+		for xx := uint32(0); xx < rle.width; xx++ {
+			if cell := world.rleCell(rle, yy, xx, color); cell != DEAD {
+				world.SetCell(y+int64(yy), x+int64(xx), cell)
+			}
+		}
+	}
+	return true
+}
+
+func (world *SparseWorld) Clear() {
+	world.chunks = make(map[chunkCoords]*chunk)
+}
+
+//GetBounds returns the smallest rectangle containing every cell that isn't dead, or false if there are none
+func (world *SparseWorld) GetBounds() (minY, minX, maxY, maxX int64, ok bool) {
+	for coords, c := range world.chunks {
+		for y := int64(0); y < CHUNK_SIZE; y++ {
+			for x := int64(0); x < CHUNK_SIZE; x++ {
+				if c[y][x] == DEAD {
+					continue
+				}
+				cellY, cellX := coords.y*CHUNK_SIZE+y, coords.x*CHUNK_SIZE+x
+				if !ok {
+					minY, minX, maxY, maxX, ok = cellY, cellX, cellY, cellX, true
+					continue
+				}
+				if cellY < minY {
+					minY = cellY
+				} else if cellY > maxY {
+					maxY = cellY
+				}
+				if cellX < minX {
+					minX = cellX
+				} else if cellX > maxX {
+					maxX = cellX
+				}
+			}
+		}
+	}
+	return minY, minX, maxY, maxX, ok
+}
+
+//GetFlattenedData flattens the rectangle with its top-left corner at (y, x) in the same form as the World
+func (world *SparseWorld) GetFlattenedData(y, x int64, height, width uint32) []uint32 {
+	flat := flattener{data: make([]uint32, 0)}
+	for cellY := y; cellY < y+int64(height); cellY++ {
+		for cellX := x; cellX < x+int64(width); {
+			coords, cy, cx := chunkOf(cellY, cellX)
+			//the rest of the row that lies within this chunk
+			run := CHUNK_SIZE - cx
+			if remaining := x + int64(width) - cellX; remaining < run {
+				run = remaining
+			}
+			if c, ok := world.chunks[coords]; ok {
+				for i := int64(0); i < run; i++ {
+					flat.add(c[cy][cx+i])
+				}
+			} else {
+				flat.addDead(uint32(run))
+			}
+			cellX += run
+		}
+	}
+	return flat.finish()
+}
+
+//GetFrame returns the top-left corner and size of the part of the world sent to clients: its bounding box, or when
+//that's more than MAX_FRAME_SIZE across, a window of that size around the median of the chunks, where most of the
+//cells are, rather than whatever escaped the furthest
+func (world *SparseWorld) GetFrame() (y, x int64, height, width uint32) {
+	minY, minX, maxY, maxX, ok := world.GetBounds()
+	if !ok {
+		return 0, 0, 0, 0
+	}
+	ys := make([]int64, 0, len(world.chunks))
+	xs := make([]int64, 0, len(world.chunks))
+	for coords := range world.chunks {
+		ys = append(ys, coords.y)
+		xs = append(xs, coords.x)
+	}
+	y, height = frameAxis(minY, maxY, ys)
+	x, width = frameAxis(minX, maxX, xs)
+	return y, x, height, width
+}
+
+//frameAxis returns the start and length of the frame along one axis, from the smallest and largest coordinates of the
+//cells, and the coordinates of the chunks
+func frameAxis(min, max int64, chunks []int64) (int64, uint32) {
+	//the distance is computed unsigned, so it can't overflow
+	if uint64(max)-uint64(min) < MAX_FRAME_SIZE {
+		return min, uint32(max-min) + 1
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i] < chunks[j] })
+	start := chunks[len(chunks)/2]*CHUNK_SIZE + CHUNK_SIZE/2 - MAX_FRAME_SIZE/2
+	//the window stays within the bounding box
+	if start < min {
+		start = min
+	} else if start > max-MAX_FRAME_SIZE+1 {
+		start = max - MAX_FRAME_SIZE + 1
+	}
+	return start, MAX_FRAME_SIZE
+}
+
+//worldData holds the frame of the world, and the cells within it
+func (world *SparseWorld) worldData() *message.WorldData {
+	y, x, height, width := world.GetFrame()
+	return &message.WorldData{
+		Data:      world.GetFlattenedData(y, x, height, width),
+		Tick:      world.tick,
+		Width:     width,
+		Height:    height,
+		OriginY:   y,
+		OriginX:   x,
+		Ownership: isOwnership(world.colors),
+		ColorRule: world.colors.Name(),
+	}
+}
+
+//ToMinProtoBytes always includes the frame, as it changes as the world evolves
+func (world *SparseWorld) ToMinProtoBytes(paused bool) ([]byte, error) {
+	worldMsg := world.worldData()
+	worldMsg.Paused = paused
+	return worldDataMessage(worldMsg)
+}
+
+func (world *SparseWorld) ToFullProtoBytes() ([]byte, error) {
+	worldMsg := world.worldData()
+	worldMsg.Rule = world.rule
+	worldMsg.States = world.states
+	return worldDataMessage(worldMsg)
+}
+
+//activeChunks returns every allocated chunk, and the neighbors of chunks with alive cells on their edges, which are
+//the only places where cells can be born
+func (world *SparseWorld) activeChunks() []chunkCoords {
+	active := make(map[chunkCoords]bool, len(world.chunks))
+	for coords, c := range world.chunks {
+		active[coords] = true
+		var top, bottom, left, right bool
+		for i := 0; i < CHUNK_SIZE; i++ {
+			top = top || isAliveBool(c[0][i])
+			bottom = bottom || isAliveBool(c[CHUNK_SIZE-1][i])
+			left = left || isAliveBool(c[i][0])
+			right = right || isAliveBool(c[i][CHUNK_SIZE-1])
+		}
+		//which rows and columns of neighboring chunks can see alive cells of this one
+		edges := [3][3]bool{
+			{isAliveBool(c[0][0]), top, isAliveBool(c[0][CHUNK_SIZE-1])},
+			{left, true, right},
+			{isAliveBool(c[CHUNK_SIZE-1][0]), bottom, isAliveBool(c[CHUNK_SIZE-1][CHUNK_SIZE-1])},
+		}
+		for dy := int64(-1); dy <= 1; dy++ {
+			for dx := int64(-1); dx <= 1; dx++ {
+				if edges[dy+1][dx+1] {
+					active[chunkCoords{y: coords.y + dy, x: coords.x + dx}] = true
+				}
+			}
+		}
+	}
+	chunks := make([]chunkCoords, 0, len(active))
+	for coords := range active {
+		chunks = append(chunks, coords)
+	}
+	return chunks
+}
+
+//fills the grid with the chunk and a border of 1 cell taken from its neighbors, so every cell of the chunk can be
+//evaluated like an inner cell of the World
+func (world *SparseWorld) paddedChunkGrid(coords chunkCoords, grid DataGrid) {
+	for dy := int64(-1); dy <= 1; dy++ {
+		for dx := int64(-1); dx <= 1; dx++ {
+			c := world.chunks[chunkCoords{y: coords.y + dy, x: coords.x + dx}]
+			//the part of the neighbor that lands in the grid, in the neighbor's coordinates
+			minY, maxY := int64(0), int64(CHUNK_SIZE)
+			if dy == -1 {
+				minY = CHUNK_SIZE - 1
+			} else if dy == 1 {
+				maxY = 1
+			}
+			minX, maxX := int64(0), int64(CHUNK_SIZE)
+			if dx == -1 {
+				minX = CHUNK_SIZE - 1
+			} else if dx == 1 {
+				maxX = 1
+			}
+			for y := minY; y < maxY; y++ {
+				row := grid[y+1+dy*CHUNK_SIZE]
+				for x := minX; x < maxX; x++ {
+					if c == nil {
+						row[x+1+dx*CHUNK_SIZE] = DEAD
+					} else {
+						row[x+1+dx*CHUNK_SIZE] = c[y][x]
+					}
+				}
+			}
+		}
+	}
+}
+
+//tickChunk returns the next state of the chunk, or nil if every cell in it is dead
+func (world *SparseWorld) tickChunk(coords chunkCoords, grid DataGrid) *chunk {
+	world.paddedChunkGrid(coords, grid)
+	next := &chunk{}
+	empty := true
+	for y := uint32(1); y <= CHUNK_SIZE; y++ {
+		//chunks start on even rows, so the row parity is the same as in the chunk
+		mask := world.neighborhoodMasks[(y-1)&1]
+		for x := uint32(1); x <= CHUNK_SIZE; x++ {
+			neighborhood := grid.InnerNeighborsValue(y, x) & mask
+			var cell uint32
+			if isAliveBool(grid[y][x]) {
+				cell = world.nextAliveState(&grid, y, x, neighborhood)
+			} else {
+				cell = world.nextDeadState(&grid, y, x, neighborhood)
+			}
+			next[y-1][x-1] = cell
+			empty = empty && cell == DEAD
+		}
+	}
+	if empty {
+		return nil
+	}
+	return next
+}
+
+//SetWorkers sets the number of workers used to tick the world
+func (world *SparseWorld) SetWorkers(workers uint32) {
+	if workers == 0 {
+		workers = 1
+	}
+	if world.pool != nil && world.pool.workers != workers {
+		world.pool.stop()
+		world.pool = nil
+	}
+	world.workers = workers
+}
+
+//Close stops the workers of the world
+func (world *SparseWorld) Close() {
+	if world.pool != nil {
+		world.pool.stop()
+		world.pool = nil
+	}
+}
+
+//Tick advances the world by one generation, sharing out the active chunks between the workers
+func (world *SparseWorld) Tick() {
+	if world.pool == nil {
+		world.pool = newWorkerPool(world.workers)
+	}
+	active := world.activeChunks()
+	next := make([]*chunk, len(active))
+
+	workers := int(world.workers)
+	world.pool.run(workers, func(w int) {
+		grid := make(DataGrid, CHUNK_SIZE+2)
+		for i := range grid {
+			grid[i] = make([]uint32, CHUNK_SIZE+2)
+		}
+		for i := w; i < len(active); i += workers {
+			next[i] = world.tickChunk(active[i], grid)
+		}
+	})
+
+	chunks := make(map[chunkCoords]*chunk, len(active))
+	for i, coords := range active {
+		if next[i] != nil {
+			chunks[coords] = next[i]
+		}
+	}
+	world.chunks = chunks
+	world.tick++
+}
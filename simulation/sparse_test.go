@@ -0,0 +1,132 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+)
+
+var rPentomino = RLE{
+	name:   "r-pentomino",
+	width:  3,
+	height: 3,
+	data: [][]bool{
+		{false, true, true},
+		{true, true, false},
+		{false, true, false},
+	},
+}
+
+func TestSparseWorld_MatchesWorld(t *testing.T) {
+	//the sparse world is offset so the pattern crosses chunk boundaries and negative coordinates
+	const offset = 100
+	for _, rule := range []string{CONWAY_RULE, "B36/S23", "B2/S345/C4", "B2/S34H"} {
+		world, err := NewWorldWithRule(200, 200, rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sparse, err := NewSparseWorld(rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		world.PlaceRLEAtCoords(rPentomino, offset, offset, FULL)
+		sparse.PlaceRLEAtCoords(rPentomino, 0, 0, FULL)
+
+		for i := 0; i < 150; i++ {
+			world.Tick()
+			sparse.Tick()
+			for y := uint32(0); y < world.height; y++ {
+				for x := uint32(0); x < world.width; x++ {
+					cell := sparse.GetCell(int64(y)-offset, int64(x)-offset)
+					if (*world.data)[y][x] != cell {
+						t.Fatalf("%s tick %d: cell (%d, %d) is %08x, expected %08x", rule, i, int64(y)-offset,
+							int64(x)-offset, cell, (*world.data)[y][x])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSparseWorld_FreesChunks(t *testing.T) {
+	sparse, err := NewSparseWorld(CONWAY_RULE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//a glider travelling 4 chunks away, leaving empty chunks behind
+	sparse.PlaceRLEAtCoords(glider, -2, -2, FULL)
+	for i := 0; i < 4*4*CHUNK_SIZE; i++ {
+		sparse.Tick()
+		if sparse.GetChunkCount() > 4 {
+			t.Fatalf("tick %d: %d chunks allocated for a glider", i, sparse.GetChunkCount())
+		}
+	}
+	minY, minX, maxY, maxX, ok := sparse.GetBounds()
+	if !ok || minY != 4*CHUNK_SIZE-2 || minX != 4*CHUNK_SIZE-2 || maxY != minY+2 || maxX != minX+2 {
+		t.Fatalf("glider bounds are (%d, %d) to (%d, %d)", minY, minX, maxY, maxX)
+	}
+
+	sparse.MarkAlive(-1000, -1000)
+	sparse.Clear()
+	sparse.MarkAlive(-1000, -1000)
+	sparse.Tick()
+	if sparse.GetChunkCount() != 0 {
+		t.Fatalf("expected no chunks once every cell has died, got %d", sparse.GetChunkCount())
+	}
+	if _, _, _, _, ok := sparse.GetBounds(); ok {
+		t.Fatal("expected no bounds for an empty world")
+	}
+}
+
+func TestSparseWorld_GetFlattenedData(t *testing.T) {
+	world := NewConwayWorld(10, 10)
+	sparse, err := NewSparseWorld(CONWAY_RULE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	world.PlaceRLEAtCoords(glider, 3, 4, FULL)
+	sparse.PlaceRLEAtCoords(glider, -CHUNK_SIZE-2, -1, FULL)
+
+	expected := world.GetFlattenedData()
+	actual := sparse.GetFlattenedData(-CHUNK_SIZE-5, -5, 10, 10)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestNewSparseWorld(t *testing.T) {
+	for _, rule := range []string{"B03/S23", "R5,C0,M1,S34..58,B34..45,NM", "B3/S23/C1"} {
+		if _, err := NewSparseWorld(rule); err == nil {
+			t.Errorf("expected an error for rule %s", rule)
+		}
+	}
+}
+
+func TestSparseWorld_GetFrame(t *testing.T) {
+	world, _ := NewSparseWorld(CONWAY_RULE)
+	world.PlaceRLEAtCoords(glider, -2, -2, FULL)
+	if y, x, height, width := world.GetFrame(); y != -2 || x != -2 || height != 3 || width != 3 {
+		t.Fatalf("expected the frame to be the bounding box, got %dx%d at (%d, %d)", width, height, x, y)
+	}
+
+	//most of the cells stay near the origin, while single cells are very far away, in both directions
+	for i := int64(0); i < 5; i++ {
+		world.PlaceRLEAtCoords(glider, i*CHUNK_SIZE, 0, FULL)
+	}
+	world.MarkAlive(math.MaxInt64, math.MaxInt64)
+	world.MarkAlive(-1<<62, 0)
+	y, x, height, width := world.GetFrame()
+	if height != MAX_FRAME_SIZE || width != MAX_FRAME_SIZE {
+		t.Fatalf("expected a frame of %d cells across, got %dx%d", MAX_FRAME_SIZE, width, height)
+	}
+	if y > -2 || y+MAX_FRAME_SIZE < 4*CHUNK_SIZE+3 || x > -2 || x+MAX_FRAME_SIZE < 3 {
+		t.Errorf("expected the frame to hold the gliders, got (%d, %d)", x, y)
+	}
+	if data := world.GetFlattenedData(y, x, height, width); len(data) == 0 {
+		t.Error("expected the frame to be flattened")
+	}
+}
@@ -0,0 +1,190 @@
+package simulation
+
+import (
+	"errors"
+)
+
+//Stabilization describes a world that has become periodic: every generation since Tick repeats Period generations
+//later. A Period of 1 means the world has stopped changing (apart from the ages and colors of its cells)
+type Stabilization struct {
+	Tick       uint64
+	Period     uint64
+	DetectedAt uint64
+	Population uint64
+}
+
+//stabilityDetector hashes the states of the cells of every generation (but not their ages or colors), and looks for a
+//hash that was already seen within the last maxPeriod generations. The hashes are kept per region, so only the regions
+//that changed last tick need to be hashed again
+type stabilityDetector struct {
+	maxPeriod uint64
+	//the hashes and populations of the regions, with the perimeter (or the whole world, if it has no regions) last
+	regionHashes      []uint64
+	regionPopulations []uint64
+	rehashAll         bool
+	//the hashes of consecutive generations, starting at firstTick
+	firstTick     uint64
+	hashes        []uint64
+	seen          map[uint64]uint64
+	stabilization *Stabilization
+}
+
+const FNV_OFFSET uint64 = 14695981039346656037
+const FNV_PRIME uint64 = 1099511628211
+
+//EnableStabilizationDetection starts looking for the world becoming periodic, with a period of up to maxPeriod
+func (world *World) EnableStabilizationDetection(maxPeriod uint32) error {
+	if maxPeriod == 0 {
+		return errors.New("the maximum period must be at least 1")
+	}
+	world.stability = &stabilityDetector{maxPeriod: uint64(maxPeriod)}
+	world.stability.reset()
+	world.detectStabilization()
+	return nil
+}
+
+func (world *World) DisableStabilizationDetection() {
+	world.stability = nil
+}
+
+//GetStabilization returns how the world has become periodic, or false if it hasn't (or detection isn't enabled)
+func (world *World) GetStabilization() (Stabilization, bool) {
+	if world.stability == nil || world.stability.stabilization == nil {
+		return Stabilization{}, false
+	}
+	return *world.stability.stabilization, true
+}
+
+//resetStability forgets the generations seen so far, as the world was changed by something other than its rule
+func (world *World) resetStability() {
+	if world.stability != nil {
+		world.stability.reset()
+	}
+}
+
+func (detector *stabilityDetector) reset() {
+	detector.rehashAll = true
+	detector.hashes = nil
+	detector.seen = make(map[uint64]uint64)
+	detector.stabilization = nil
+}
+
+//hashEdits hashes the world as it is after being edited, so the edited generation is the first one that later
+//generations are compared against
+func (world *World) hashEdits() {
+	if world.stability != nil && len(world.stability.hashes) == 0 {
+		world.detectStabilization()
+	}
+}
+
+//hashCells hashes the states of the cells in the rectangle, returning the hash and how many cells are alive
+func (world *World) hashCells(minY, minX, maxY, maxX uint32, hash uint64) (uint64, uint64) {
+	population := uint64(0)
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			cell := (*world.data)[y][x]
+			if isAliveBool(cell) {
+				population++
+			}
+			hash = (hash ^ uint64(CellState(cell))) * FNV_PRIME
+		}
+	}
+	return hash, population
+}
+
+func (world *World) hashPerimeter() (uint64, uint64) {
+	hash, population := FNV_OFFSET, uint64(0)
+	for _, side := range [][4]uint32{
+		{0, 0, 1, world.width},
+		{world.height - 1, 0, world.height, world.width},
+		{1, 0, world.height - 1, 1},
+		{1, world.width - 1, world.height - 1, world.width},
+	} {
+		var sidePopulation uint64
+		hash, sidePopulation = world.hashCells(side[0], side[1], side[2], side[3], hash)
+		population += sidePopulation
+	}
+	return hash, population
+}
+
+//detectStabilization hashes the current generation, and checks whether it has been seen before
+func (world *World) detectStabilization() {
+	detector := world.stability
+	if detector == nil {
+		return
+	}
+	regions := len(world.regions)
+	if len(detector.regionHashes) != regions+1 {
+		detector.regionHashes = make([]uint64, regions+1)
+		detector.regionPopulations = make([]uint64, regions+1)
+		detector.rehashAll = true
+	}
+	rehashAll := detector.rehashAll || world.changedAll
+	if regions == 0 {
+		detector.regionHashes[0], detector.regionPopulations[0] = world.hashCells(0, 0, world.height, world.width,
+			FNV_OFFSET)
+	} else {
+		for i, r := range world.regions {
+			if rehashAll || world.changed[i] {
+				//the region's index is part of the hash, so identical regions in different places don't cancel out
+				detector.regionHashes[i], detector.regionPopulations[i] = world.hashCells(r.minY, r.minX, r.maxY,
+					r.maxX, (FNV_OFFSET^uint64(i))*FNV_PRIME)
+			}
+		}
+		if rehashAll || world.changed[regions] {
+			detector.regionHashes[regions], detector.regionPopulations[regions] = world.hashPerimeter()
+		}
+	}
+	detector.rehashAll = false
+
+	hash, population := uint64(0), uint64(0)
+	for i := range detector.regionHashes {
+		hash ^= detector.regionHashes[i]
+		population += detector.regionPopulations[i]
+	}
+	if world.margolus != nil {
+		//the blocks alternate, so the generations of block rules only repeat on the same partition
+		hash ^= world.tick % 2 * FNV_PRIME
+	}
+	detector.record(world.tick, hash, population)
+}
+
+func (detector *stabilityDetector) record(tick, hash, population uint64) {
+	if len(detector.hashes) == 0 || tick != detector.firstTick+uint64(len(detector.hashes)) {
+		//the generations have to be consecutive
+		detector.firstTick = tick
+		detector.hashes = detector.hashes[:0]
+		detector.seen = make(map[uint64]uint64)
+	}
+	previous, seen := detector.seen[hash]
+	detector.hashes = append(detector.hashes, hash)
+	detector.seen[hash] = tick
+	if uint64(len(detector.hashes)) > detector.maxPeriod+1 {
+		oldest := detector.hashes[0]
+		if detector.seen[oldest] == detector.firstTick {
+			delete(detector.seen, oldest)
+		}
+		detector.hashes = detector.hashes[1:]
+		detector.firstTick++
+	}
+
+	if !seen || detector.stabilization != nil {
+		return
+	}
+	//the cycle began at the earliest generation that matches the one a period later
+	period := tick - previous
+	start := previous
+	for start > detector.firstTick {
+		before := start - 1 - detector.firstTick
+		if detector.hashes[before] != detector.hashes[before+period] {
+			break
+		}
+		start--
+	}
+	detector.stabilization = &Stabilization{
+		Tick:       start,
+		Period:     period,
+		DetectedAt: tick,
+		Population: population,
+	}
+}
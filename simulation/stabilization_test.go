@@ -0,0 +1,79 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestWorld_Stabilization(t *testing.T) {
+	block := RLE{width: 2, height: 2, data: [][]bool{{true, true}, {true, true}}}
+	blinker := RLE{width: 3, height: 1, data: [][]bool{{true, true, true}}}
+	//becomes a block after a generation
+	tromino := RLE{width: 2, height: 2, data: [][]bool{{true, true}, {true, false}}}
+
+	tests := []struct {
+		name      string
+		rle       RLE
+		topology  Topology
+		maxPeriod uint32
+		expected  Stabilization
+	}{
+		{"block", block, BOUNDED, 10, Stabilization{Tick: 0, Period: 1, DetectedAt: 1, Population: 4}},
+		{"blinker", blinker, BOUNDED, 10, Stabilization{Tick: 0, Period: 2, DetectedAt: 2, Population: 3}},
+		{"tromino", tromino, BOUNDED, 10, Stabilization{Tick: 1, Period: 1, DetectedAt: 2, Population: 4}},
+		//the glider needs 4*40 generations to come back around the torus
+		{"torus glider", glider, TORUS, 200, Stabilization{Tick: 0, Period: 160, DetectedAt: 160, Population: 5}},
+		{"torus glider beyond the maximum period", glider, TORUS, 100, Stabilization{}},
+	}
+	for _, test := range tests {
+		for _, colors := range []ColorRule{RGBBlend{}, MajorityColors{}} {
+			world := NewConwayWorld(40, 40)
+			world.SetTopology(test.topology)
+			world.SetColorRule(colors)
+			world.PlaceRLEAtCoords(test.rle, 20, 20, FULL)
+			err := world.EnableStabilizationDetection(test.maxPeriod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 300; i++ {
+				world.Tick()
+			}
+			stabilization, ok := world.GetStabilization()
+			if ok != (test.expected.Period != 0) || stabilization != test.expected {
+				t.Errorf("%s: expected %+v, got %+v (%t)", test.name, test.expected, stabilization, ok)
+			}
+		}
+	}
+}
+
+func TestWorld_StabilizationAfterEdit(t *testing.T) {
+	world := NewConwayWorld(40, 40)
+	err := world.EnableStabilizationDetection(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	world.PlaceRLEAtCoords(RLE{width: 3, height: 1, data: [][]bool{{true, true, true}}}, 5, 5, FULL)
+	for i := 0; i < 5; i++ {
+		world.Tick()
+	}
+	if stabilization, ok := world.GetStabilization(); !ok || stabilization.Period != 2 {
+		t.Fatalf("expected the blinker to have period 2, got %+v", stabilization)
+	}
+
+	//edits start the detection over, from the edited generation
+	world.PlaceRLEAtCoords(glider, 30, 30, FULL)
+	if _, ok := world.GetStabilization(); ok {
+		t.Fatal("expected the edit to reset the stabilization")
+	}
+	for i := 0; i < 100; i++ {
+		world.Tick()
+	}
+	//the glider turns into a block in the corner, next to the blinker
+	stabilization, ok := world.GetStabilization()
+	if !ok || stabilization.Period != 2 || stabilization.Tick <= 5 || stabilization.Population != 7 {
+		t.Fatalf("expected the world to stabilize with period 2 after the edit, got %+v", stabilization)
+	}
+
+	if err := world.EnableStabilizationDetection(0); err == nil {
+		t.Fatal("expected an error for a maximum period of 0")
+	}
+}
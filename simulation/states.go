@@ -0,0 +1,142 @@
+package simulation
+
+import (
+	"fmt"
+	"strings"
+)
+
+//StateRule is a rule whose cells have several states, and change state depending on the states of their neighbors
+//rather than on how many of them are alive. Cells are stored like the cells of Generations rules, with their state in
+//the lower byte (see generations.go), so state 1 is the only one that's alive, and the color of their state in the top
+//3 bytes
+type StateRule interface {
+	Name() string
+	States() uint32
+	//Next returns the next state of a cell, given the states of its neighbors in the order of the neighborhood bits
+	Next(state uint32, neighbors *[8]uint32) uint32
+	//StateColors returns the color of every state. State 0 is empty, so its cells are dead whatever its color
+	StateColors() []uint32
+}
+
+//the states of Wireworld
+const (
+	WIREWORLD_EMPTY     uint32 = 0
+	WIREWORLD_HEAD      uint32 = 1
+	WIREWORLD_TAIL      uint32 = 2
+	WIREWORLD_CONDUCTOR uint32 = 3
+)
+
+//Wireworld moves electrons along wires of conductor: a head becomes a tail, a tail becomes conductor again, and
+//conductor becomes a head when 1 or 2 of its neighbors are heads
+type Wireworld struct{}
+
+func (Wireworld) Name() string {
+	return "Wireworld"
+}
+
+func (Wireworld) States() uint32 {
+	return 4
+}
+
+func (Wireworld) Next(state uint32, neighbors *[8]uint32) uint32 {
+	switch state {
+	case WIREWORLD_HEAD:
+		return WIREWORLD_TAIL
+	case WIREWORLD_TAIL:
+		return WIREWORLD_CONDUCTOR
+	case WIREWORLD_CONDUCTOR:
+		heads := 0
+		for _, neighbor := range neighbors {
+			if neighbor == WIREWORLD_HEAD {
+				heads++
+			}
+		}
+		if heads == 1 || heads == 2 {
+			return WIREWORLD_HEAD
+		}
+		return WIREWORLD_CONDUCTOR
+	}
+	return WIREWORLD_EMPTY
+}
+
+//the same colors as Golly: blue heads, white tails and orange conductor
+func (Wireworld) StateColors() []uint32 {
+	return []uint32{DEAD, 0x0080FF00, 0xFFFFFF00, 0xFF800000}
+}
+
+//NamedRules are the multi-state rules that can be run by name, keyed by their lowercase name
+var NamedRules = map[string]StateRule{
+	"wireworld": Wireworld{},
+}
+
+//ParseNamedRule returns the named rule, ignoring case
+func ParseNamedRule(name string) (StateRule, bool) {
+	rule, ok := NamedRules[strings.ToLower(name)]
+	return rule, ok
+}
+
+//StateCell returns the cell to place for the state: the state's color for named rules, and otherwise a cell of the
+//color, refractory for the higher states of Generations rules. State 0 is DEAD
+func (rules *cellRules) StateCell(state, color uint32) (uint32, error) {
+	if state >= rules.states {
+		return DEAD, fmt.Errorf("the rule has no state %d", state)
+	}
+	if rules.stateRule != nil {
+		color = rules.stateRule.StateColors()[state]
+	}
+	switch state {
+	case 0:
+		return DEAD, nil
+	case 1:
+		return color&^ALIVE_NEW | ALIVE_NEW, nil
+	}
+	return refractoryCell(color&^ALIVE_NEW, state), nil
+}
+
+//rleCell returns the cell to place for the cell of the RLE at (y, x), or DEAD if there's nothing to place. Cells of
+//two-state RLEs are in state 1, and the cells of multi-state RLEs in states the rule doesn't have aren't placed
+func (rules *cellRules) rleCell(rle RLE, y, x, color uint32) uint32 {
+	if !rle.data[y][x] {
+		return DEAD
+	}
+	state := uint32(1)
+	if rle.states != nil {
+		state = uint32(rle.states[y][x])
+	}
+	cell, err := rules.StateCell(state, color)
+	if err != nil {
+		return DEAD
+	}
+	return cell
+}
+
+//tickStates evaluates a named rule on every cell, looking across the seams of the topology for the neighbors of the
+//perimeter cells
+func (world *World) tickStates() {
+	stripes := world.stripes
+	world.workerPool().run(len(stripes), func(i int) {
+		world.statesWorker(stripes[i][0], stripes[i][1])
+	})
+	world.swapBuffers()
+	world.dataChanged()
+}
+
+func (world *World) statesWorker(minY, maxY uint32) {
+	var neighbors [8]uint32
+	for y := minY; y < maxY; y++ {
+		perimeterRow := y == 0 || y == world.height-1
+		for x := uint32(0); x < world.width; x++ {
+			perimeter := perimeterRow || x == 0 || x == world.width-1
+			for i, offset := range neighborOffsets {
+				if perimeter {
+					neighbors[i] = CellState(world.cellAt(int64(y)+int64(offset[0]), int64(x)+int64(offset[1])))
+				} else {
+					neighbors[i] = CellState((*world.data)[int(y)+offset[0]][int(x)+offset[1]])
+				}
+			}
+			state := world.stateRule.Next(CellState((*world.data)[y][x]), &neighbors)
+			//states the rule doesn't have become DEAD
+			(*world.dataBuffer)[y][x], _ = world.StateCell(state, DEAD)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package simulation
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLoadRLE_MultiState(t *testing.T) {
+	f, err := ioutil.TempFile("", "*.rle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	//pA is state 25, and rows can end early
+	_, err = f.WriteString("x = 4, y = 2, rule = Test\n2.AB$pAC!\n")
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rle, err := LoadRLE(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]uint8{{0, 0, 1, 2}, {25, 3, 0, 0}}
+	for y, row := range expected {
+		for x, state := range row {
+			if rle.states[y][x] != state || rle.data[y][x] != (state != 0) {
+				t.Errorf("expected (%d, %d) to be in state %d, got %d", y, x, state, rle.states[y][x])
+			}
+		}
+	}
+
+	//two-state RLEs don't have states
+	rle, err = LoadRLE("../data/glider.rle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rle.states != nil {
+		t.Error("expected a two-state RLE not to have states")
+	}
+}
+
+func TestWorld_Wireworld(t *testing.T) {
+	clock, err := LoadRLE("../data/wireworldclock.rle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, topology := range []Topology{BOUNDED, TORUS} {
+		world, err := NewWorldWithRule(10, 10, "WireWorld")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if world.GetRule() != "Wireworld" || world.GetStates() != 4 {
+			t.Fatalf("expected a 4 state Wireworld, got %d states of %s", world.GetStates(), world.GetRule())
+		}
+		world.SetTopology(topology)
+		//placed across the seam of the torus
+		y, x := uint32(2), uint32(2)
+		if topology == TORUS {
+			y, x = 8, 7
+		}
+		world.PlaceRLEAtCoords(clock, y, x, 0xFF000000)
+		start := copyData(&world)
+		state := func(yy, xx uint32) uint32 {
+			return CellState(world.GetCell(int64((y+yy)%10), int64((x+xx)%10)))
+		}
+		if state(2, 2) != WIREWORLD_HEAD || state(2, 1) != WIREWORLD_TAIL || state(0, 1) != WIREWORLD_CONDUCTOR {
+			t.Fatalf("%s: expected the clock to be placed in its states", topology)
+		}
+		if world.GetCell(int64((y+2)%10), int64((x+2)%10))&^0xFF != (Wireworld{}).StateColors()[WIREWORLD_HEAD] {
+			t.Fatalf("%s: expected the cells to have the colors of their states", topology)
+		}
+
+		//the electron goes around the loop, which has 8 cells
+		for i := 0; i < 4; i++ {
+			world.Tick()
+		}
+		if state(0, 2) != WIREWORLD_HEAD || state(0, 3) != WIREWORLD_TAIL || state(2, 2) != WIREWORLD_CONDUCTOR {
+			t.Fatalf("%s: expected the electron to be halfway around the loop", topology)
+		}
+		for i := 0; i < 4; i++ {
+			world.Tick()
+		}
+		checkData(t, &world, start)
+	}
+}
+
+func TestWorld_StateCell(t *testing.T) {
+	wireworld, _ := NewWorldWithRule(10, 10, "wireworld")
+	generations, _ := NewWorldWithRule(10, 10, "B2/S/C3")
+	tests := []struct {
+		world    World
+		state    uint32
+		expected uint32
+	}{
+		{wireworld, WIREWORLD_EMPTY, DEAD},
+		{wireworld, WIREWORLD_HEAD, 0x0080FFFF},
+		{wireworld, WIREWORLD_CONDUCTOR, 0xFF8000FA},
+		{generations, 1, 0xFF0000FF},
+		{generations, 2, 0xFF0000FC},
+	}
+	for _, test := range tests {
+		cell, err := test.world.StateCell(test.state, 0xFF000000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cell != test.expected || CellState(cell) != test.state {
+			t.Errorf("%s: expected state %d to be %08x, got %08x", test.world.GetRule(), test.state, test.expected,
+				cell)
+		}
+	}
+	if _, err := wireworld.StateCell(4, FULL); err == nil {
+		t.Error("expected an error for a state Wireworld doesn't have")
+	}
+	if _, err := NewSparseWorld("Wireworld"); err == nil {
+		t.Error("expected an error running a named rule on an unbounded world")
+	}
+}
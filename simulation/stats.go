@@ -0,0 +1,261 @@
+package simulation
+
+import (
+	"errors"
+	"github.com/denverquane/golife/proto/message"
+	"google.golang.org/protobuf/proto"
+)
+
+type ColorCount struct {
+	Color uint32
+	Count uint64
+}
+
+//TickStats are the statistics of a generation of the world. Births and deaths don't include the edits made to the
+//world since the generation before
+type TickStats struct {
+	Tick       uint64
+	Population uint64
+	Births     uint64
+	Deaths     uint64
+	//the live cells by the stats color closest to their own, or by their exact owner in ownership mode, in the order
+	//given to SetStatsColors
+	Colors []ColorCount
+}
+
+//statsTracker keeps the statistics of the last maxTicks generations. The population and color counts are kept up to
+//date from the cells that change every tick, and only counted from scratch after the world is edited
+type statsTracker struct {
+	maxTicks int
+	ticks    []TickStats
+	//the index of the oldest generation once ticks is full
+	oldest      int
+	colors      []uint32
+	population  uint64
+	colorCounts []uint64
+	recount     bool
+	//set in ownership mode, where the colors are owners and cells only count under their own
+	owners bool
+	//set by engines that count the changes as they make them, rather than leaving them to be compared afterwards
+	counted *statsCounter
+}
+
+//statsCounter accumulates the changes of some of the cells of a tick
+type statsCounter struct {
+	births uint64
+	deaths uint64
+	colors []int64
+}
+
+//EnableStats starts computing the statistics of every generation as the world ticks, keeping the last maxTicks of them
+func (world *World) EnableStats(maxTicks uint32) error {
+	if maxTicks == 0 {
+		return errors.New("the stats need at least 1 tick")
+	}
+	world.stats = &statsTracker{maxTicks: int(maxTicks), recount: true}
+	return nil
+}
+
+func (world *World) DisableStats() {
+	world.stats = nil
+}
+
+//SetStatsColors sets the colors (such as the colors of the players) that the live cells are counted by. Every live
+//cell is counted under the color closest to its own, as blending the colors of cells creates new ones. In ownership
+//mode the colors are owned cells, and cells are only counted under their owner
+func (world *World) SetStatsColors(colors []uint32) {
+	if world.stats == nil {
+		return
+	}
+	world.stats.colors = make([]uint32, len(colors))
+	for i, color := range colors {
+		world.stats.colors[i] = color &^ 0xFF
+	}
+	world.stats.recount = true
+}
+
+//GetStats returns the statistics of the generations kept, oldest first
+func (world *World) GetStats() []TickStats {
+	if world.stats == nil {
+		return nil
+	}
+	stats := world.stats
+	ticks := make([]TickStats, 0, len(stats.ticks))
+	ticks = append(ticks, stats.ticks[stats.oldest:]...)
+	return append(ticks, stats.ticks[:stats.oldest]...)
+}
+
+//GetLatestStats returns the statistics of the last generation, or false if there are none
+func (world *World) GetLatestStats() (TickStats, bool) {
+	if world.stats == nil || len(world.stats.ticks) == 0 {
+		return TickStats{}, false
+	}
+	stats := world.stats
+	if len(stats.ticks) < stats.maxTicks {
+		return stats.ticks[len(stats.ticks)-1], true
+	}
+	return stats.ticks[(stats.oldest+stats.maxTicks-1)%stats.maxTicks], true
+}
+
+//recountStats must be called when the world's data is edited, so the population is counted from scratch
+func (world *World) recountStats() {
+	if world.stats != nil {
+		world.stats.recount = true
+	}
+}
+
+//closestColor returns the index of the stats color closest to the color of the cell, or -1 if there are none
+func (stats *statsTracker) closestColor(cell uint32) int {
+	closest, closestDistance := -1, 0
+	for i, color := range stats.colors {
+		distance := 0
+		for shift := uint(8); shift < 32; shift += 8 {
+			difference := int((cell>>shift)&0xFF) - int((color>>shift)&0xFF)
+			distance += difference * difference
+		}
+		if closest == -1 || distance < closestDistance {
+			closest, closestDistance = i, distance
+		}
+	}
+	return closest
+}
+
+//colorIndex returns the index of the stats color the cell is counted under, or -1 if it isn't counted under any
+func (stats *statsTracker) colorIndex(cell uint32) int {
+	if !stats.owners {
+		return stats.closestColor(cell)
+	}
+	for i, color := range stats.colors {
+		if cell&^0xFF == color {
+			return i
+		}
+	}
+	return -1
+}
+
+func (stats *statsTracker) newCounter() *statsCounter {
+	return &statsCounter{colors: make([]int64, len(stats.colors))}
+}
+
+//count records the change of a cell from old to cell
+func (stats *statsTracker) count(counter *statsCounter, old, cell uint32) {
+	wasAlive, alive := isAliveBool(old), isAliveBool(cell)
+	if !wasAlive && alive {
+		counter.births++
+	} else if wasAlive && !alive {
+		counter.deaths++
+	}
+	if len(stats.colors) == 0 || (wasAlive && alive && old&^0xFF == cell&^0xFF) {
+		return
+	}
+	if i := stats.colorIndex(old); wasAlive && i >= 0 {
+		counter.colors[i]--
+	}
+	if i := stats.colorIndex(cell); alive && i >= 0 {
+		counter.colors[i]++
+	}
+}
+
+func (counter *statsCounter) add(other *statsCounter) {
+	counter.births += other.births
+	counter.deaths += other.deaths
+	for i := range counter.colors {
+		counter.colors[i] += other.colors[i]
+	}
+}
+
+//recordStats records the statistics of the generation the world just ticked to. Unless the engine counted the changes
+//itself, the cells of the regions that changed are compared to the generation before, left in the data buffer
+func (world *World) recordStats() {
+	stats := world.stats
+	if stats == nil {
+		return
+	}
+	if owners := world.GetOwnership(); owners != stats.owners {
+		//the cells were counted the other way
+		stats.owners = owners
+		stats.recount = true
+	}
+	counter := stats.counted
+	stats.counted = nil
+	if counter == nil {
+		counter = stats.newCounter()
+		for _, r := range world.GetChangedRegions() {
+			for y := r[0]; y < r[2]; y++ {
+				for x := r[1]; x < r[3]; x++ {
+					if old, cell := (*world.dataBuffer)[y][x], (*world.data)[y][x]; old != cell {
+						stats.count(counter, old, cell)
+					}
+				}
+			}
+		}
+	}
+
+	if stats.recount {
+		stats.population = 0
+		stats.colorCounts = make([]uint64, len(stats.colors))
+		for y := uint32(0); y < world.height; y++ {
+			for x := uint32(0); x < world.width; x++ {
+				if cell := (*world.data)[y][x]; isAliveBool(cell) {
+					stats.population++
+					if i := stats.colorIndex(cell); i >= 0 {
+						stats.colorCounts[i]++
+					}
+				}
+			}
+		}
+		stats.recount = false
+	} else {
+		stats.population += counter.births - counter.deaths
+		for i, change := range counter.colors {
+			stats.colorCounts[i] = uint64(int64(stats.colorCounts[i]) + change)
+		}
+	}
+
+	tickStats := TickStats{
+		Tick:       world.tick,
+		Population: stats.population,
+		Births:     counter.births,
+		Deaths:     counter.deaths,
+		Colors:     make([]ColorCount, len(stats.colors)),
+	}
+	for i, color := range stats.colors {
+		tickStats.Colors[i] = ColorCount{Color: color, Count: stats.colorCounts[i]}
+	}
+	if len(stats.ticks) < stats.maxTicks {
+		stats.ticks = append(stats.ticks, tickStats)
+	} else {
+		stats.ticks[stats.oldest] = tickStats
+		stats.oldest = (stats.oldest + 1) % stats.maxTicks
+	}
+}
+
+func (tickStats TickStats) toProto() *message.TickStats {
+	msg := &message.TickStats{
+		Tick:       tickStats.Tick,
+		Population: tickStats.Population,
+		Births:     tickStats.Births,
+		Deaths:     tickStats.Deaths,
+	}
+	for _, color := range tickStats.Colors {
+		msg.Colors = append(msg.Colors, &message.ColorCount{Color: color.Color, Count: color.Count})
+	}
+	return msg
+}
+
+//StatsToProtoBytes marshals the statistics of some generations as a STATS message
+func StatsToProtoBytes(ticks []TickStats) ([]byte, error) {
+	statsMsg := message.Stats{}
+	for _, tickStats := range ticks {
+		statsMsg.Ticks = append(statsMsg.Ticks, tickStats.toProto())
+	}
+	statsMarshalled, err := proto.Marshal(&statsMsg)
+	if err != nil {
+		return nil, err
+	}
+	msg := message.Message{
+		Type:    message.MessageType_STATS,
+		Content: statsMarshalled,
+	}
+	return proto.Marshal(&msg)
+}
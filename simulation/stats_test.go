@@ -0,0 +1,107 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestWorld_Stats(t *testing.T) {
+	palette := []uint32{0xFF000000, 0x0000FF00}
+	for _, rule := range []string{CONWAY_RULE, "B2/S/C3", "R2,C0,M1,S2..5,B3..4,NM"} {
+		for _, colors := range []ColorRule{RGBBlend{}, MajorityColors{}} {
+			world, err := NewWorldWithRule(40, 50, rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = world.EnableStats(10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			world.SetStatsColors(palette)
+			world.SetColorRule(colors)
+			randomSoup(&world, 7, palette)
+
+			for i := 0; i < 30; i++ {
+				if i == 20 {
+					//edits aren't births
+					world.PlaceRLEAtCoords(glider, 5, 5, 0xFF000000)
+				}
+				previous := copyData(&world)
+				world.Tick()
+
+				expected := TickStats{Tick: world.tick, Colors: make([]ColorCount, len(palette))}
+				for i, color := range palette {
+					expected.Colors[i].Color = color
+				}
+				for y := uint32(0); y < world.height; y++ {
+					for x := uint32(0); x < world.width; x++ {
+						wasAlive, alive := isAliveBool(previous[y][x]), isAliveBool((*world.data)[y][x])
+						if alive {
+							expected.Population++
+							expected.Colors[world.stats.closestColor((*world.data)[y][x])].Count++
+						}
+						if alive && !wasAlive {
+							expected.Births++
+						} else if wasAlive && !alive {
+							expected.Deaths++
+						}
+					}
+				}
+				stats, ok := world.GetLatestStats()
+				if !ok || stats.Tick != expected.Tick || stats.Population != expected.Population ||
+					stats.Births != expected.Births || stats.Deaths != expected.Deaths ||
+					stats.Colors[0] != expected.Colors[0] || stats.Colors[1] != expected.Colors[1] {
+					t.Fatalf("rule %s, %s colors: expected %+v, got %+v", rule, colors.Name(), expected, stats)
+				}
+			}
+
+			//only the last 10 ticks are kept, oldest first
+			ticks := world.GetStats()
+			if len(ticks) != 10 || ticks[0].Tick != 21 || ticks[9].Tick != 30 {
+				t.Fatalf("expected the stats of ticks 21 to 30, got %d ticks from %d", len(ticks), ticks[0].Tick)
+			}
+		}
+	}
+}
+
+func TestStatsTracker_ClosestColor(t *testing.T) {
+	world := NewConwayWorld(10, 10)
+	if world.SetStatsColors([]uint32{FULL}); world.stats != nil {
+		t.Fatal("expected the stats colors to be ignored while the stats are disabled")
+	}
+	err := world.EnableStats(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	world.SetStatsColors([]uint32{0xFF0000FF, 0x00FF00FF, 0x0000FFFF})
+	//the colors are compared without their lower byte
+	if closest := world.stats.closestColor(0xE0201001); closest != 0 {
+		t.Errorf("expected red to be closest, got %d", closest)
+	}
+	if closest := world.stats.closestColor(0x4050C0FF); closest != 2 {
+		t.Errorf("expected blue to be closest, got %d", closest)
+	}
+	if err := world.EnableStats(0); err == nil {
+		t.Error("expected an error keeping the stats of no ticks")
+	}
+}
+
+func TestWorld_OwnershipStats(t *testing.T) {
+	world := NewConwayWorld(20, 20)
+	world.SetColorRule(OwnershipColors{})
+	if err := world.EnableStats(5); err != nil {
+		t.Fatal(err)
+	}
+	//owners 2 and 3 would be the closest colors to each other, but the cells of owner 3 aren't counted for owner 2
+	world.SetStatsColors([]uint32{OwnedCell(1), OwnedCell(2)})
+	block := patternRLE("block", "OO", "OO")
+	world.PlaceRLEAtCoords(glider, 2, 2, OwnedCell(1))
+	world.PlaceRLEAtCoords(block, 12, 2, OwnedCell(2))
+	world.PlaceRLEAtCoords(block, 12, 12, OwnedCell(3))
+	for i := 0; i < 8; i++ {
+		world.Tick()
+		stats, ok := world.GetLatestStats()
+		if !ok || stats.Population != 13 || stats.Colors[0].Count != 5 || stats.Colors[1].Count != 4 {
+			t.Fatalf("tick %d: expected 5 cells of owner 1 and 4 of owner 2 out of 13, got %+v", i+1, stats)
+		}
+	}
+}
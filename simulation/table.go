@@ -0,0 +1,454 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//TableRule is a multi-state rule loaded from a Golly .rule file, whose @TABLE section lists transitions: the state of a
+//cell and of its neighbors, followed by the state it becomes. The transitions are tried in order with every symmetry of
+//the table, and a cell that matches none of them stays in its state. Inputs can be variables, and every input with the
+//same variable in a transition has to be in the same state. The @COLORS section gives the colors of the states
+type TableRule struct {
+	name        string
+	states      uint32
+	vonNeumann  bool
+	transitions []tableTransition
+	//the orders to read the neighbors of a cell in for every symmetry of the table, unless the neighbors can be in any
+	//order (permute)
+	symmetries [][]int
+	permute    bool
+	colors     []uint32
+
+	//the next states of the neighborhoods seen so far, which are shared by the workers
+	cacheLock sync.RWMutex
+	cache     map[[9]uint8]uint8
+}
+
+type tableTransition struct {
+	//the states every input can be in: the cell, then its neighbors in the order of the table
+	inputs [][]bool
+	//the first input with the same variable as every input, or -1 when it's the first (or a state)
+	bound []int
+	//the state the cell becomes, or the state of the input at outputInput when it isn't -1
+	output      uint8
+	outputInput int
+}
+
+//the most neighborhoods a TableRule keeps the next states of before starting over
+const MAX_TABLE_CACHE = 1 << 20
+
+//the neighbors of a Moore table are in the order N, NE, E, SE, S, SW, W, NW, which are these neighborhood bits
+var mooreTableOrder = []int{1, 2, 3, 4, 5, 6, 7, 0}
+
+//the neighbors of a von Neumann table are in the order N, E, S, W
+var vonNeumannTableOrder = []int{1, 3, 5, 7}
+
+//LoadRuleFile loads a Golly .rule file with a @TABLE section
+func LoadRuleFile(path string) (*TableRule, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRuleTable(string(buf))
+}
+
+//ParseRuleTable parses the contents of a Golly .rule file. Only the @RULE, @TABLE and @COLORS sections are read, and
+//tables need a Moore or vonNeumann neighborhood
+func ParseRuleTable(text string) (*TableRule, error) {
+	rule := &TableRule{cache: make(map[[9]uint8]uint8)}
+	vars := make(map[string][]uint8)
+	symmetries := "none"
+	var colorLines [][]int
+	section := ""
+	hasTable := false
+	for i, line := range strings.Split(text, "\n") {
+		if comment := strings.Index(line, "#"); comment >= 0 {
+			line = line[:comment]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line[0] == '@' {
+			fields := strings.Fields(line)
+			section = fields[0]
+			if section == "@RULE" && len(fields) > 1 {
+				rule.name = fields[1]
+			}
+			hasTable = hasTable || section == "@TABLE"
+			continue
+		}
+		var err error
+		switch section {
+		case "@TABLE":
+			err = rule.parseTableLine(line, vars, &symmetries)
+		case "@COLORS":
+			var values []int
+			values, err = parseInts(line)
+			if err == nil && len(values) != 4 && len(values) != 6 {
+				err = errors.New("colors are a state and its red, green and blue, or the 2 colors of a gradient")
+			}
+			colorLines = append(colorLines, values)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+	}
+	if rule.name == "" {
+		return nil, errors.New("the rule has no @RULE name")
+	}
+	if !hasTable || rule.states == 0 {
+		return nil, fmt.Errorf("%s has no @TABLE with n_states", rule.name)
+	}
+	if err := rule.setSymmetries(symmetries); err != nil {
+		return nil, err
+	}
+	rule.setColors(colorLines)
+	return rule, nil
+}
+
+func (rule *TableRule) parseTableLine(line string, vars map[string][]uint8, symmetries *string) error {
+	if strings.HasPrefix(line, "n_states:") {
+		states, err := strconv.ParseUint(strings.TrimSpace(line[len("n_states:"):]), 10, 32)
+		if err != nil || states < 2 || states > MAX_STATES {
+			return fmt.Errorf("number of states must be between 2 and %d", MAX_STATES)
+		}
+		rule.states = uint32(states)
+		return nil
+	}
+	if strings.HasPrefix(line, "neighborhood:") {
+		switch neighborhood := strings.TrimSpace(line[len("neighborhood:"):]); neighborhood {
+		case "Moore":
+			rule.vonNeumann = false
+		case "vonNeumann":
+			rule.vonNeumann = true
+		default:
+			return fmt.Errorf("the %s neighborhood isn't supported", neighborhood)
+		}
+		return nil
+	}
+	if strings.HasPrefix(line, "symmetries:") {
+		*symmetries = strings.TrimSpace(line[len("symmetries:"):])
+		return nil
+	}
+	if rule.states == 0 {
+		return errors.New("n_states has to come before the variables and transitions")
+	}
+	if strings.HasPrefix(line, "var ") {
+		split := strings.SplitN(line[len("var "):], "=", 2)
+		if len(split) != 2 {
+			return errors.New("variables are written var name={states}")
+		}
+		values := strings.Trim(strings.TrimSpace(split[1]), "{}")
+		var states []uint8
+		for _, value := range strings.Split(values, ",") {
+			valueStates, err := rule.parseStates(strings.TrimSpace(value), vars)
+			if err != nil {
+				return err
+			}
+			states = append(states, valueStates...)
+		}
+		vars[strings.TrimSpace(split[0])] = states
+		return nil
+	}
+	return rule.parseTransition(line, vars)
+}
+
+//parseStates parses a state, or the states of a variable
+func (rule *TableRule) parseStates(value string, vars map[string][]uint8) ([]uint8, error) {
+	if states, ok := vars[value]; ok {
+		return states, nil
+	}
+	state, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("unknown variable %s", value)
+	}
+	if uint32(state) >= rule.states {
+		return nil, fmt.Errorf("the rule has no state %d", state)
+	}
+	return []uint8{uint8(state)}, nil
+}
+
+//parseTransition parses the inputs and output of a transition, which are separated by commas, spaces, or nothing at all
+//when the rule has at most 10 states
+func (rule *TableRule) parseTransition(line string, vars map[string][]uint8) error {
+	var tokens []string
+	if strings.Contains(line, ",") {
+		tokens = strings.Split(line, ",")
+	} else if strings.ContainsAny(line, " \t") || rule.states > 10 {
+		tokens = strings.Fields(line)
+	} else {
+		tokens = strings.Split(line, "")
+	}
+	inputs := len(mooreTableOrder) + 1
+	if rule.vonNeumann {
+		inputs = len(vonNeumannTableOrder) + 1
+	}
+	if len(tokens) != inputs+1 {
+		return fmt.Errorf("a transition needs %d states, got %d", inputs+1, len(tokens))
+	}
+	transition := tableTransition{
+		inputs:      make([][]bool, inputs),
+		bound:       make([]int, inputs),
+		outputInput: -1,
+	}
+	firstInputs := make(map[string]int)
+	for i, token := range tokens[:inputs] {
+		token = strings.TrimSpace(token)
+		states, err := rule.parseStates(token, vars)
+		if err != nil {
+			return err
+		}
+		transition.inputs[i] = make([]bool, rule.states)
+		for _, state := range states {
+			transition.inputs[i][state] = true
+		}
+		transition.bound[i] = -1
+		if _, ok := vars[token]; ok {
+			if first, ok := firstInputs[token]; ok {
+				transition.bound[i] = first
+			} else {
+				firstInputs[token] = i
+			}
+		}
+	}
+	output := strings.TrimSpace(tokens[inputs])
+	if first, ok := firstInputs[output]; ok {
+		transition.outputInput = first
+	} else {
+		states, err := rule.parseStates(output, vars)
+		if err != nil {
+			return err
+		}
+		if len(states) != 1 {
+			return fmt.Errorf("the output variable %s isn't an input", output)
+		}
+		transition.output = states[0]
+	}
+	rule.transitions = append(rule.transitions, transition)
+	return nil
+}
+
+//setSymmetries finds the orders the neighbors of a transition can be matched in, as rotations of the neighborhood
+//(by 45 degrees for a Moore neighborhood, and 90 degrees for a von Neumann one) followed by a reflection
+func (rule *TableRule) setSymmetries(symmetries string) error {
+	neighbors := len(mooreTableOrder)
+	if rule.vonNeumann {
+		neighbors = len(vonNeumannTableOrder)
+	}
+	//the steps of 45 or 90 degrees every rotation turns by
+	step := 0
+	reflect := false
+	switch symmetries {
+	case "none":
+	case "permute":
+		rule.permute = true
+		return nil
+	case "reflect":
+		reflect = true
+	case "rotate4", "rotate4reflect":
+		step = neighbors / 4
+		reflect = symmetries == "rotate4reflect"
+	case "rotate8", "rotate8reflect":
+		if rule.vonNeumann {
+			return fmt.Errorf("%s needs a Moore neighborhood", symmetries)
+		}
+		step = 1
+		reflect = symmetries == "rotate8reflect"
+	default:
+		return fmt.Errorf("the %s symmetries aren't supported", symmetries)
+	}
+	rule.symmetries = nil
+	for rotation := 0; rotation < neighbors; rotation += step {
+		for _, reflected := range []bool{false, true} {
+			if reflected && !reflect {
+				continue
+			}
+			order := make([]int, neighbors)
+			for i := range order {
+				j := i
+				if reflected {
+					//mirrored left to right, keeping N (and S) in place
+					j = (neighbors - i) % neighbors
+				}
+				order[i] = (j + rotation) % neighbors
+			}
+			rule.symmetries = append(rule.symmetries, order)
+		}
+		if step == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+//setColors colors the states with a gradient from red to yellow (like Golly), and then with the colors of the @COLORS
+//section in order
+func (rule *TableRule) setColors(colorLines [][]int) {
+	gradient := func(from, to []int) {
+		for state := uint32(1); state < rule.states; state++ {
+			color := uint32(0)
+			for i := range from {
+				value := from[i]
+				if rule.states > 2 {
+					value += (to[i] - from[i]) * int(state-1) / int(rule.states-2)
+				}
+				color = color<<8 | uint32(value)&0xFF
+			}
+			rule.colors[state] = color << 8
+		}
+	}
+	rule.colors = make([]uint32, rule.states)
+	gradient([]int{255, 0, 0}, []int{255, 255, 0})
+	for _, values := range colorLines {
+		if len(values) == 6 {
+			gradient(values[:3], values[3:])
+		} else if values[0] > 0 && uint32(values[0]) < rule.states {
+			rule.colors[values[0]] = uint32(values[1]&0xFF)<<24 | uint32(values[2]&0xFF)<<16 | uint32(values[3]&0xFF)<<8
+		}
+	}
+}
+
+func parseInts(line string) ([]int, error) {
+	fields := strings.Fields(line)
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (rule *TableRule) Name() string {
+	return rule.name
+}
+
+func (rule *TableRule) States() uint32 {
+	return rule.states
+}
+
+func (rule *TableRule) StateColors() []uint32 {
+	return rule.colors
+}
+
+func (rule *TableRule) Next(state uint32, neighbors *[8]uint32) uint32 {
+	order := mooreTableOrder
+	if rule.vonNeumann {
+		order = vonNeumannTableOrder
+	}
+	var key [9]uint8
+	key[0] = uint8(state)
+	for _, bit := range order {
+		key[bit+1] = uint8(neighbors[bit])
+	}
+	rule.cacheLock.RLock()
+	next, ok := rule.cache[key]
+	rule.cacheLock.RUnlock()
+	if ok {
+		return uint32(next)
+	}
+
+	cells := make([]uint8, len(order))
+	for i, bit := range order {
+		cells[i] = key[bit+1]
+	}
+	next = uint8(state)
+	values := make([]uint8, len(order)+1)
+	for i := range rule.transitions {
+		if output, ok := rule.match(&rule.transitions[i], uint8(state), cells, values); ok {
+			next = output
+			break
+		}
+	}
+	rule.cacheLock.Lock()
+	if len(rule.cache) >= MAX_TABLE_CACHE {
+		rule.cache = make(map[[9]uint8]uint8)
+	}
+	rule.cache[key] = next
+	rule.cacheLock.Unlock()
+	return uint32(next)
+}
+
+//match returns the output of the transition if it matches the cell and its neighbors, in the order of the table, with
+//any of the symmetries of the table
+func (rule *TableRule) match(transition *tableTransition, state uint8, cells []uint8, values []uint8) (uint8, bool) {
+	values[0] = state
+	if !transition.accepts(0, values) {
+		return 0, false
+	}
+	matched := false
+	if rule.permute {
+		matched = transition.permute(1, cells, values, make([]bool, len(cells)))
+	} else {
+		for _, order := range rule.symmetries {
+			matched = true
+			for i, neighbor := range order {
+				values[i+1] = cells[neighbor]
+				if !transition.accepts(i+1, values) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				break
+			}
+		}
+	}
+	if !matched {
+		return 0, false
+	}
+	if transition.outputInput >= 0 {
+		return values[transition.outputInput], true
+	}
+	return transition.output, true
+}
+
+//accepts returns whether the input can be in the state it has in values, given the states of the inputs before it
+func (transition *tableTransition) accepts(input int, values []uint8) bool {
+	if !transition.inputs[input][values[input]] {
+		return false
+	}
+	bound := transition.bound[input]
+	return bound < 0 || values[bound] == values[input]
+}
+
+//permute returns whether the neighbors that aren't used yet can be assigned to the inputs from input on
+func (transition *tableTransition) permute(input int, cells []uint8, values []uint8, used []bool) bool {
+	if input == len(values) {
+		return true
+	}
+	for i, cell := range cells {
+		if used[i] {
+			continue
+		}
+		values[input] = cell
+		if transition.accepts(input, values) {
+			used[i] = true
+			matched := transition.permute(input+1, cells, values, used)
+			used[i] = false
+			if matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//RegisterRule makes a multi-state rule (like one loaded with LoadRuleFile) available by name, unless a rule already has
+//its name
+func RegisterRule(rule StateRule) error {
+	name := strings.ToLower(rule.Name())
+	if _, ok := NamedRules[name]; ok {
+		return fmt.Errorf("there's already a rule named %s", rule.Name())
+	}
+	NamedRules[name] = rule
+	return nil
+}
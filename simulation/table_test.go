@@ -0,0 +1,112 @@
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLoadRuleFile(t *testing.T) {
+	rule, err := LoadRuleFile("../data/BriansBrain.rule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Name() != "BriansBrain" || rule.States() != 3 {
+		t.Fatalf("expected a 3 state rule named BriansBrain, got %d states of %s", rule.States(), rule.Name())
+	}
+	colors := rule.StateColors()
+	if colors[0] != DEAD || colors[1] != 0xFFFFFF00 || colors[2] != 0x0080FF00 {
+		t.Errorf("expected the colors of @COLORS, got %08x", colors)
+	}
+	if err := RegisterRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	defer delete(NamedRules, "briansbrain")
+	if RegisterRule(rule) == nil || RegisterRule(Wireworld{}) == nil {
+		t.Error("expected an error registering a rule with a name that's taken")
+	}
+
+	//the table runs exactly like the Generations rule
+	table, err := NewWorldWithRule(40, 40, "briansbrain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generations, _ := NewWorldWithRule(40, 40, "B2/S/C3")
+	random := rand.New(rand.NewSource(8))
+	for y := int64(10); y < 30; y++ {
+		for x := int64(10); x < 30; x++ {
+			if random.Intn(3) == 0 {
+				cell, _ := table.StateCell(1, FULL)
+				table.SetCell(y, x, cell)
+				generations.SetCell(y, x, FULL)
+			}
+		}
+	}
+	for i := 0; i < 30; i++ {
+		table.Tick()
+		generations.Tick()
+		for y := int64(0); y < 40; y++ {
+			for x := int64(0); x < 40; x++ {
+				if CellState(table.GetCell(y, x)) != CellState(generations.GetCell(y, x)) {
+					t.Fatalf("tick %d: expected (%d, %d) to be in state %d, got %d", i+1, y, x,
+						CellState(generations.GetCell(y, x)), CellState(table.GetCell(y, x)))
+				}
+			}
+		}
+	}
+}
+
+func TestParseRuleTable(t *testing.T) {
+	//a cell is born next to exactly one orthogonal neighbor, and dies when its neighbors are all in the same state
+	rule, err := ParseRuleTable(`@RULE Test
+@TABLE
+n_states:2
+neighborhood:vonNeumann
+symmetries:rotate4
+var a={0,1}
+010001
+1,a,a,a,a,0
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		state     uint32
+		neighbors [8]uint32
+		expected  uint32
+	}{
+		//N, then W
+		{0, [8]uint32{0, 1, 0, 0, 0, 0, 0, 0}, 1},
+		{0, [8]uint32{0, 0, 0, 0, 0, 0, 0, 1}, 1},
+		//diagonal neighbors aren't in the neighborhood
+		{0, [8]uint32{1, 0, 1, 0, 0, 0, 0, 0}, 0},
+		{0, [8]uint32{0, 1, 0, 1, 0, 0, 0, 0}, 0},
+		{1, [8]uint32{0, 1, 0, 1, 0, 1, 0, 1}, 0},
+		{1, [8]uint32{1, 0, 1, 0, 1, 0, 1, 0}, 0},
+		{1, [8]uint32{0, 1, 0, 1, 0, 1, 0, 0}, 1},
+	}
+	for _, test := range tests {
+		if next := rule.Next(test.state, &test.neighbors); next != test.expected {
+			t.Errorf("expected %d with neighbors %v to become %d, got %d", test.state, test.neighbors, test.expected,
+				next)
+		}
+	}
+	//without @COLORS, the states are a gradient from red
+	if rule.StateColors()[1] != 0xFF000000 {
+		t.Errorf("expected state 1 to be red, got %08x", rule.StateColors()[1])
+	}
+
+	for _, text := range []string{
+		"@TABLE\nn_states:2\n",
+		"@RULE Test\n",
+		"@RULE Test\n@TABLE\nneighborhood:Moore\n0,0,0,0,0,0,0,0,0,1\n",
+		"@RULE Test\n@TABLE\nn_states:2\nneighborhood:hexagonal\n",
+		"@RULE Test\n@TABLE\nn_states:2\n0,1,0,1\n",
+		"@RULE Test\n@TABLE\nn_states:2\n0,1,0,1,0,1,0,1,x,1\n",
+		"@RULE Test\n@TABLE\nn_states:2\n0,1,0,1,0,1,0,1,0,2\n",
+		"@RULE Test\n@TABLE\nn_states:2\nneighborhood:vonNeumann\nsymmetries:rotate8\n",
+	} {
+		if _, err := ParseRuleTable(text); err == nil {
+			t.Errorf("expected an error parsing %q", text)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Topology int
+
+const (
+	//everything beyond the edges of the world is dead
+	BOUNDED Topology = 0
+	//top joins to bottom, left joins to right
+	TORUS Topology = 1
+	//left joins to right, and top joins to bottom with a horizontal flip
+	KLEIN_BOTTLE Topology = 2
+	//top joins to bottom with a horizontal flip, and left joins to right with a vertical flip
+	CROSS_SURFACE Topology = 3
+)
+
+var TopologyNames = map[Topology]string{
+	BOUNDED:       "bounded",
+	TORUS:         "torus",
+	KLEIN_BOTTLE:  "klein",
+	CROSS_SURFACE: "cross",
+}
+
+func ParseTopology(name string) (Topology, error) {
+	for topology, topologyName := range TopologyNames {
+		if strings.ToLower(name) == topologyName {
+			return topology, nil
+		}
+	}
+	return BOUNDED, fmt.Errorf("unknown topology %s", name)
+}
+
+func (topology Topology) String() string {
+	return TopologyNames[topology]
+}
+
+//SetTopology fails for hexagonal rules if the rows wouldn't alternate across the top/bottom seam (see hexagonalSeams)
+func (world *World) SetTopology(topology Topology) error {
+	if err := world.hexagonalSeams(topology, world.height); err != nil {
+		return err
+	}
+	world.topology = topology
+	//the generations seen so far might not follow each other under the new topology
+	world.resetStability()
+	return nil
+}
+
+//hexagonalSeams returns an error if a hexagonal rule would be lopsided across the top/bottom seam of a world of the
+//height, where a cell could count another as a neighbor without being counted back. The odd rows are offset to the
+//right, so across a plain seam the rows have to keep alternating (an even height), while across a flipped seam the
+//offset is mirrored, so the rows have to repeat (an odd height)
+func (world *World) hexagonalSeams(topology Topology, height uint32) error {
+	if world.neighborhoodMasks[0] == world.neighborhoodMasks[1] {
+		return nil
+	}
+	switch topology {
+	case TORUS:
+		if height%2 != 0 {
+			return errors.New("hexagonal rules need an even height on a torus")
+		}
+	case KLEIN_BOTTLE, CROSS_SURFACE:
+		if height%2 == 0 {
+			return fmt.Errorf("hexagonal rules need an odd height on a %s", topology)
+		}
+	}
+	return nil
+}
+
+func (world *World) GetTopology() Topology {
+	return world.topology
+}
+
+//wrapCoords maps coordinates that may lie beyond the edges of the world onto the cell they refer to, according to the
+//world's topology. Returns false if the coordinates don't refer to any cell (always the case when bounded)
+func (world *World) wrapCoords(y, x int64) (uint32, uint32, bool) {
+	height := int64(world.height)
+	width := int64(world.width)
+	if y >= 0 && y < height && x >= 0 && x < width {
+		return uint32(y), uint32(x), true
+	}
+
+	//how many times the coordinates cross the top/bottom and left/right seams
+	crossingsY := floorDiv(y, height)
+	crossingsX := floorDiv(x, width)
+	switch world.topology {
+	case TORUS:
+	case KLEIN_BOTTLE:
+		if crossingsY%2 != 0 {
+			x = width - 1 - x
+		}
+	case CROSS_SURFACE:
+		if crossingsY%2 != 0 {
+			x = width - 1 - x
+		}
+		if crossingsX%2 != 0 {
+			y = height - 1 - y
+		}
+	default:
+		return 0, 0, false
+	}
+	return uint32(y - floorDiv(y, height)*height), uint32(x - floorDiv(x, width)*width), true
+}
+
+func floorDiv(a, b int64) int64 {
+	if a < 0 {
+		return -((-a + b - 1) / b)
+	}
+	return a / b
+}
+
+//cellAt returns the cell at coordinates that may lie beyond the edges of the world, or DEAD if there's no such cell
+func (world *World) cellAt(y, x int64) uint32 {
+	//across a corner of a cross-surface the diagonal neighbor is ambiguous (all 4 corners meet), so treat it as dead
+	if world.topology == CROSS_SURFACE && (y < 0 || y >= int64(world.height)) && (x < 0 || x >= int64(world.width)) {
+		return DEAD
+	}
+	wy, wx, ok := world.wrapCoords(y, x)
+	if !ok {
+		return DEAD
+	}
+	return (*world.data)[wy][wx]
+}
+
+//perimeterNeighborhoodGrid fills the 3x3 grid with the cell at (y, x) and its neighbors, looking across the seams of
+//the topology, so that the cell can be evaluated at (1, 1) of the grid exactly like an inner cell
+func (world *World) perimeterNeighborhoodGrid(y, x uint32, grid *DataGrid) {
+	for yy := int64(-1); yy <= 1; yy++ {
+		for xx := int64(-1); xx <= 1; xx++ {
+			(*grid)[yy+1][xx+1] = world.cellAt(int64(y)+yy, int64(x)+xx)
+		}
+	}
+}
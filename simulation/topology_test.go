@@ -0,0 +1,164 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func aliveCells(world *World) map[[2]uint32]bool {
+	cells := make(map[[2]uint32]bool)
+	for y := uint32(0); y < world.height; y++ {
+		for x := uint32(0); x < world.width; x++ {
+			if isAliveBool((*world.data)[y][x]) {
+				cells[[2]uint32{y, x}] = true
+			}
+		}
+	}
+	return cells
+}
+
+var glider = RLE{
+	name:   "glider",
+	width:  3,
+	height: 3,
+	data: [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{true, true, true},
+	},
+}
+
+func TestWorld_TorusGlider(t *testing.T) {
+	rle := glider
+	world := NewConwayWorld(8, 8)
+	world.SetTopology(TORUS)
+	world.PlaceRLEAtCoords(rle, 2, 2, FULL)
+	start := aliveCells(&world)
+
+	//a glider travels one cell diagonally every 4 generations, so it returns to its starting position after 4*8
+	for i := 0; i < 32; i++ {
+		world.Tick()
+	}
+	end := aliveCells(&world)
+	if len(start) != 5 || len(end) != len(start) {
+		t.Fatalf("Expected 5 cells, got %d", len(end))
+	}
+	for cell := range start {
+		if !end[cell] {
+			t.Errorf("Expected cell %v to be alive", cell)
+		}
+	}
+}
+
+func TestWorld_wrapCoords(t *testing.T) {
+	world := NewConwayWorld(10, 20)
+	if _, _, ok := world.wrapCoords(-1, 5); ok {
+		t.Fail()
+	}
+
+	world.SetTopology(TORUS)
+	if y, x, _ := world.wrapCoords(-1, 20); y != 9 || x != 0 {
+		t.Errorf("Torus wrapped to (%d, %d)", y, x)
+	}
+
+	world.SetTopology(KLEIN_BOTTLE)
+	if y, x, _ := world.wrapCoords(-1, 3); y != 9 || x != 16 {
+		t.Errorf("Klein bottle wrapped to (%d, %d)", y, x)
+	}
+	if y, x, _ := world.wrapCoords(3, -1); y != 3 || x != 19 {
+		t.Errorf("Klein bottle wrapped to (%d, %d)", y, x)
+	}
+
+	world.SetTopology(CROSS_SURFACE)
+	if y, x, _ := world.wrapCoords(10, 3); y != 0 || x != 16 {
+		t.Errorf("Cross-surface wrapped to (%d, %d)", y, x)
+	}
+	if y, x, _ := world.wrapCoords(2, 20); y != 7 || x != 0 {
+		t.Errorf("Cross-surface wrapped to (%d, %d)", y, x)
+	}
+}
+
+func TestWorld_PlaceRLEAtCoordsWrapped(t *testing.T) {
+	rle := glider
+	world := NewConwayWorld(10, 10)
+	if world.PlaceRLEAtCoords(rle, 8, 8, FULL) {
+		t.Fail()
+	}
+	world.SetTopology(TORUS)
+	if !world.PlaceRLEAtCoords(rle, 8, 8, FULL) {
+		t.Fail()
+	}
+	if len(aliveCells(&world)) != 5 {
+		t.Fail()
+	}
+}
+
+//hexNeighbors returns the cells that each cell of a world is a neighbor of, found by the births around it under B1
+func hexNeighbors(t *testing.T, height, width uint32, topology Topology) map[[2]uint32]map[[2]uint32]bool {
+	neighbors := make(map[[2]uint32]map[[2]uint32]bool)
+	for y := uint32(0); y < height; y++ {
+		for x := uint32(0); x < width; x++ {
+			world, err := NewWorldWithRule(height, width, "B1/SH")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := world.SetTopology(topology); err != nil {
+				t.Fatal(err)
+			}
+			world.SetCell(int64(y), int64(x), FULL)
+			world.Tick()
+			neighbors[[2]uint32{y, x}] = aliveCells(&world)
+		}
+	}
+	return neighbors
+}
+
+func TestWorld_HexagonalSeams(t *testing.T) {
+	tests := []struct {
+		topology Topology
+		height   uint32
+	}{
+		{TORUS, 6},
+		{KLEIN_BOTTLE, 7},
+		{CROSS_SURFACE, 7},
+	}
+	for _, test := range tests {
+		for _, width := range []uint32{7, 8} {
+			neighbors := hexNeighbors(t, test.height, width, test.topology)
+			for cell, cellNeighbors := range neighbors {
+				//the corners of a cross-surface lose their diagonal neighbors, which are ambiguous
+				if test.topology != CROSS_SURFACE && len(cellNeighbors) != 6 {
+					t.Errorf("%v of %dx%d: cell %v has %d neighbors", test.topology, test.height, width, cell, len(cellNeighbors))
+				}
+				for neighbor := range cellNeighbors {
+					if !neighbors[neighbor][cell] {
+						t.Errorf("%v of %dx%d: %v is a neighbor of %v but not the other way around", test.topology,
+							test.height, width, cell, neighbor)
+					}
+				}
+			}
+
+			//the other parity of height would be lopsided across the top/bottom seam
+			world, _ := NewWorldWithRule(test.height+1, width, "B1/SH")
+			if world.SetTopology(test.topology) == nil {
+				t.Errorf("Expected %v to reject a height of %d", test.topology, test.height+1)
+			}
+			world, _ = NewWorldWithRule(test.height, width, "B1/SH")
+			world.SetTopology(test.topology)
+			if world.Resize(test.height+1, width, ANCHOR_TOP_LEFT) == nil || world.height != test.height {
+				t.Errorf("Expected %v not to resize to a height of %d", test.topology, test.height+1)
+			}
+		}
+	}
+}
+
+func TestParseTopology(t *testing.T) {
+	for topology, name := range TopologyNames {
+		parsed, err := ParseTopology(name)
+		if err != nil || parsed != topology {
+			t.Fail()
+		}
+	}
+	if _, err := ParseTopology("sphere"); err == nil {
+		t.Fail()
+	}
+}
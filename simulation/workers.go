@@ -0,0 +1,143 @@
+package simulation
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+type Partitioning int
+
+const (
+	//the inside of the world is split into a grid of tiles, with the perimeter handled separately
+	TILES Partitioning = 0
+	//the inside of the world is split into stripes of whole rows
+	ROW_STRIPES Partitioning = 1
+)
+
+type poolTask struct {
+	f  func(int)
+	i  int
+	wg *sync.WaitGroup
+}
+
+//workerPool keeps its goroutines around between ticks, waiting for tasks
+type workerPool struct {
+	workers uint32
+	tasks   chan poolTask
+}
+
+func newWorkerPool(workers uint32) *workerPool {
+	pool := &workerPool{
+		workers: workers,
+		tasks:   make(chan poolTask, workers),
+	}
+	for i := uint32(0); i < workers; i++ {
+		go func() {
+			for task := range pool.tasks {
+				task.f(task.i)
+				task.wg.Done()
+			}
+		}()
+	}
+	return pool
+}
+
+//run calls f with every index from 0 to n-1 on the workers, and waits until they're all done
+func (pool *workerPool) run(n int, f func(int)) {
+	if pool.workers == 1 || n == 1 {
+		//no point handing the tasks over to another goroutine
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		pool.tasks <- poolTask{f: f, i: i, wg: &wg}
+	}
+	wg.Wait()
+}
+
+func (pool *workerPool) stop() {
+	close(pool.tasks)
+}
+
+//defaultWorkers is one worker per CPU
+func defaultWorkers() uint32 {
+	return uint32(runtime.NumCPU())
+}
+
+//SetWorkers sets the number of workers used to tick the world, and how the world is split between them. The workers
+//are started on the next tick, and kept until the number changes or the world is closed
+func (world *World) SetWorkers(workers uint32, partitioning Partitioning) {
+	if workers == 0 {
+		workers = 1
+	}
+	if world.pool != nil && world.pool.workers != workers {
+		world.pool.stop()
+		world.pool = nil
+	}
+	world.workers = workers
+	world.partitioning = partitioning
+	world.computePartitions()
+}
+
+func (world *World) GetWorkers() (uint32, Partitioning) {
+	return world.workers, world.partitioning
+}
+
+//Close stops the workers of the world
+func (world *World) Close() {
+	if world.pool != nil {
+		world.pool.stop()
+		world.pool = nil
+	}
+}
+
+func (world *World) workerPool() *workerPool {
+	if world.pool == nil {
+		world.pool = newWorkerPool(world.workers)
+	}
+	return world.pool
+}
+
+//computePartitions precomputes the tiles of the inside of the world (everything but the perimeter), and the stripes of
+//whole rows used by the engines that don't treat the perimeter separately
+func (world *World) computePartitions() {
+	world.stripes = rowStripes(world.height, world.workers)
+	world.tiles = nil
+	if world.height < 3 || world.width < 3 {
+		world.computeRegions()
+		return
+	}
+
+	//the rows and columns of tiles; when the workers aren't a perfect square there are a few more tiles than workers
+	rows, columns := world.workers, uint32(1)
+	if world.partitioning == TILES {
+		columns = uint32(math.Sqrt(float64(world.workers)))
+		rows = (world.workers + columns - 1) / columns
+	}
+	for _, stripe := range rowStripes(world.height-2, rows) {
+		for _, column := range rowStripes(world.width-2, columns) {
+			world.tiles = append(world.tiles, [4]uint32{stripe[0] + 1, column[0] + 1, stripe[1] + 1, column[1] + 1})
+		}
+	}
+	world.computeRegions()
+}
+
+//rowStripes splits the rows of the world into the given number of stripes (or one per row, if there are fewer rows)
+func rowStripes(height, stripes uint32) [][2]uint32 {
+	if stripes == 0 {
+		stripes = 1
+	}
+	if stripes > height {
+		stripes = height
+	}
+	divisions := make([][2]uint32, stripes)
+	for i := uint32(0); i < stripes; i++ {
+		divisions[i] = [2]uint32{i * height / stripes, (i + 1) * height / stripes}
+	}
+	return divisions
+}
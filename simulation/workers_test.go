@@ -0,0 +1,74 @@
+package simulation
+
+import (
+	"testing"
+)
+
+func TestWorld_computePartitions(t *testing.T) {
+	world := NewConwayWorld(37, 53)
+	for workers := uint32(1); workers <= 10; workers++ {
+		for _, partitioning := range []Partitioning{TILES, ROW_STRIPES} {
+			world.SetWorkers(workers, partitioning)
+			if len(world.tiles) < int(workers) {
+				t.Errorf("%d workers: only %d tiles", workers, len(world.tiles))
+			}
+			//every cell inside the perimeter is in exactly one tile
+			covered := make(map[[2]uint32]int)
+			for _, tile := range world.tiles {
+				if partitioning == ROW_STRIPES && (tile[1] != 1 || tile[3] != world.width-1) {
+					t.Errorf("%d workers: stripe %v doesn't span the width", workers, tile)
+				}
+				for y := tile[0]; y < tile[2]; y++ {
+					for x := tile[1]; x < tile[3]; x++ {
+						covered[[2]uint32{y, x}]++
+					}
+				}
+			}
+			for y := uint32(1); y < world.height-1; y++ {
+				for x := uint32(1); x < world.width-1; x++ {
+					if covered[[2]uint32{y, x}] != 1 {
+						t.Fatalf("%d workers: cell (%d, %d) is in %d tiles", workers, y, x, covered[[2]uint32{y, x}])
+					}
+				}
+			}
+			rows := uint32(0)
+			for _, stripe := range world.stripes {
+				rows += stripe[1] - stripe[0]
+			}
+			if rows != world.height {
+				t.Errorf("%d workers: stripes cover %d rows", workers, rows)
+			}
+		}
+	}
+	world.Close()
+}
+
+func TestWorld_TickWorkers(t *testing.T) {
+	reference := NewConwayWorld(60, 60)
+	reference.SetWorkers(1, TILES)
+	randomSoup(&reference, 2, []uint32{FULL})
+	for i := 0; i < 20; i++ {
+		reference.Tick()
+	}
+
+	for _, workers := range []uint32{2, 3, 5, 7} {
+		for _, partitioning := range []Partitioning{TILES, ROW_STRIPES} {
+			world := NewConwayWorld(60, 60)
+			world.SetWorkers(workers, partitioning)
+			randomSoup(&world, 2, []uint32{FULL})
+			for i := 0; i < 20; i++ {
+				world.Tick()
+			}
+			for y := uint32(0); y < world.height; y++ {
+				for x := uint32(0); x < world.width; x++ {
+					if (*world.data)[y][x] != (*reference.data)[y][x] {
+						t.Fatalf("%d workers: cell (%d, %d) is %08x, expected %08x", workers, y, x,
+							(*world.data)[y][x], (*reference.data)[y][x])
+					}
+				}
+			}
+			world.Close()
+		}
+	}
+	reference.Close()
+}
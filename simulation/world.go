@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/denverquane/golife/proto/message"
 	"google.golang.org/protobuf/proto"
-	"sync"
 )
 
 type DataGrid [][]uint32
@@ -19,11 +18,47 @@ type World struct {
 	width  uint32
 	height uint32
 	//indexed [y][x]!
-	data              *DataGrid
-	dataBuffer        *DataGrid
-	aliveRulesMapping map[byte]bool
-	deadRulesMapping  map[byte]bool
-	tick              uint64
+	data       *DataGrid
+	dataBuffer *DataGrid
+	cellRules
+	rule     string
+	topology Topology
+	//only set for Larger than Life rules, which use ltl instead of the neighbor mappings
+	ltl       *LtLRule
+	ltlTables *ltlTables
+	//only set for block rules, which update 2x2 blocks instead of single cells
+	margolus *MargolusRule
+	//only set for elementary rules, which only compute the bottom row and scroll the rows above it
+	elementary *ElementaryRule
+	//only set for two-state totalistic rules, which use it to tick much faster when not blending colors
+	packed *packedGrid
+	tick   uint64
+	//only set once the history is enabled
+	history *History
+	//only set once stabilization detection is enabled
+	stability *stabilityDetector
+	//the classifications of the object shapes the census has seen, by their Wechsler code
+	censusClasses map[string]objectClass
+	//only set once the stats are enabled
+	stats *statsTracker
+	//the workers are started on the first tick, and split the world into the precomputed tiles or stripes
+	workers      uint32
+	partitioning Partitioning
+	pool         *workerPool
+	tiles        [][4]uint32
+	stripes      [][2]uint32
+	//the regions of the tiles that changed last tick, and that need to be recomputed this tick
+	regions     []region
+	tileRegions [][]int
+	changed     []bool
+	dirty       []bool
+	//the regions where cells were edited since the last tick
+	edits []bool
+	//set when the data changes outside of a tick that tracks changes, so every region has to be recomputed
+	allDirty   bool
+	changedAll bool
+	//set when the whole world was edited since the last tick
+	editedAll bool
 }
 
 func (world *World) GetDims() (height uint32, width uint32) {
@@ -33,38 +68,57 @@ func (world *World) GetDims() (height uint32, width uint32) {
 const MaxCells31Bits = 4294967294
 
 func (world *World) GetFlattenedData() []uint32 {
-	data := make([]uint32, 0)
-	deadCount := uint32(0)
-	for y := uint32(0); y < world.height; y++ {
-		for x := uint32(0); x < world.width; x++ {
-			//if the cell is dead, we can use all the color bits for RLE encoding of sequential dead cells
-			if (*world.data)[y][x]&ALIVE_BIT == 0 {
-				//dead cell; start the count
-				deadCount++
-			} else {
-				if deadCount > 0 {
-					//if we reach an alive cell, add a RLE-encoded length of dead cells
-					shifted := deadCount << 1
-					data = append(data, shifted)
-
-					//append this current alive cell
-					data = append(data, (*world.data)[y][x])
-					deadCount = 0
-				} else {
-					//append the alive data normally
-					data = append(data, (*world.data)[y][x])
-				}
-			}
-			//if we're gonna overflow 32 bits, or we've reached the end of the world, add the shift
-			if (y == world.height-1 && x == world.width-1) || deadCount == MaxCells31Bits {
-				shifted := deadCount << 1
-				data = append(data, shifted)
-				deadCount = 0
-			}
+	flat := flattener{data: make([]uint32, 0)}
+	for _, row := range *world.data {
+		for _, cell := range row {
+			flat.add(cell)
 		}
 	}
 	//log.Print(data)
-	return data
+	return flat.finish()
+}
+
+//flattener run-length encodes sequential dead cells as their count shifted left by 1, and appends every other cell
+//with the ALIVE_BIT set. Refractory cells are sent as alive, with their state in the lower byte
+type flattener struct {
+	data      []uint32
+	deadCount uint32
+}
+
+func (flat *flattener) add(cell uint32) {
+	//if the cell is dead, we can use all the color bits for RLE encoding of sequential dead cells
+	if cell == DEAD {
+		flat.addDead(1)
+		return
+	}
+	if flat.deadCount > 0 {
+		//if we reach an alive cell, add a RLE-encoded length of dead cells
+		flat.data = append(flat.data, flat.deadCount<<1)
+		flat.deadCount = 0
+	}
+	flat.data = append(flat.data, cell|ALIVE_BIT)
+}
+
+func (flat *flattener) addDead(count uint32) {
+	for count > 0 {
+		added := MaxCells31Bits - flat.deadCount
+		if added > count {
+			added = count
+		}
+		flat.deadCount += added
+		count -= added
+		//if we're gonna overflow 32 bits, add the shift
+		if flat.deadCount == MaxCells31Bits {
+			flat.data = append(flat.data, flat.deadCount<<1)
+			flat.deadCount = 0
+		}
+	}
+}
+
+//finish adds the final run of dead cells (which may be empty), and returns the data
+func (flat *flattener) finish() []uint32 {
+	flat.data = append(flat.data, flat.deadCount<<1)
+	return flat.data
 }
 
 func (world *World) ToMinProtoBytes(paused bool) ([]byte, error) {
@@ -73,6 +127,7 @@ func (world *World) ToMinProtoBytes(paused bool) ([]byte, error) {
 		Tick:   world.GetTick(),
 		Paused: paused,
 	}
+	worldMsg.HistoryOldest, worldMsg.HistoryNewest, _ = world.GetHistoryRange()
 	worldMsgMarshalled, err := proto.Marshal(&worldMsg)
 	if err != nil {
 		return nil, err
@@ -88,13 +143,73 @@ func (world *World) ToMinProtoBytes(paused bool) ([]byte, error) {
 	return marshalled, nil
 }
 
+//ToChangedProtoBytes only includes the cells of the rectangles (from GetChangedRegions), for clients that have the
+//rest of the world from earlier frames
+func (world *World) ToChangedProtoBytes(regions [][4]uint32, paused bool) ([]byte, error) {
+	flat := flattener{data: make([]uint32, 0)}
+	worldMsg := message.WorldData{
+		Tick:    world.GetTick(),
+		Paused:  paused,
+		Partial: true,
+	}
+	//the rectangles are clipped to the world, in case they're from before it was resized
+	data := *world.data
+	for _, r := range regions {
+		maxY, maxX := r[2], r[3]
+		if maxY > uint32(len(data)) {
+			maxY = uint32(len(data))
+		}
+		if len(data) > 0 && maxX > uint32(len(data[0])) {
+			maxX = uint32(len(data[0]))
+		}
+		if r[0] >= maxY || r[1] >= maxX {
+			continue
+		}
+		worldMsg.ChangedRegions = append(worldMsg.ChangedRegions, r[0], r[1], maxY-r[0], maxX-r[1])
+		for y := r[0]; y < maxY; y++ {
+			for x := r[1]; x < maxX; x++ {
+				flat.add(data[y][x])
+			}
+		}
+	}
+	worldMsg.Data = flat.finish()
+	worldMsg.HistoryOldest, worldMsg.HistoryNewest, _ = world.GetHistoryRange()
+	return worldDataMessage(&worldMsg)
+}
+
+//worldDataMessage marshals the world data in a WORLD_DATA message
+func worldDataMessage(worldMsg *message.WorldData) ([]byte, error) {
+	worldMsgMarshalled, err := proto.Marshal(worldMsg)
+	if err != nil {
+		return nil, err
+	}
+	msg := message.Message{
+		Type:    message.MessageType_WORLD_DATA,
+		Content: worldMsgMarshalled,
+	}
+	marshalled, err := proto.Marshal(&msg)
+	if err != nil {
+		return nil, err
+	}
+	return marshalled, nil
+}
+
 func (world *World) ToFullProtoBytes() ([]byte, error) {
 	worldMsg := message.WorldData{
-		Data:   world.GetFlattenedData(),
-		Tick:   world.tick,
-		Width:  world.width,
-		Height: world.height,
+		Data:      world.GetFlattenedData(),
+		Tick:      world.tick,
+		Width:     world.width,
+		Height:    world.height,
+		Rule:      world.rule,
+		States:    world.states,
+		Ownership: isOwnership(world.colors),
+		ColorRule: world.colors.Name(),
+	}
+	if world.stateRule != nil {
+		worldMsg.StateColors = world.stateRule.StateColors()
 	}
+	worldMsg.Reversible = world.IsReversible()
+	worldMsg.HistoryOldest, worldMsg.HistoryNewest, _ = world.GetHistoryRange()
 	worldMsgMarshalled, err := proto.Marshal(&worldMsg)
 	if err != nil {
 		return nil, err
@@ -110,6 +225,59 @@ func (world *World) ToFullProtoBytes() ([]byte, error) {
 	return marshalled, nil
 }
 func NewConwayWorld(height, width uint32) World {
+	alive, dead := GenerateConwayNeighborsRules()
+	world := newWorld(height, width, alive, dead, CONWAY_RULE, 2)
+	world.packed = newPackedGrid(height, width, world.cellRules)
+	return world
+}
+
+//NewWorldWithRule creates a world running any Life-like or Generations rule, given in B/S ("B36/S23") or S/B ("23/36")
+//notation, a Larger than Life rule ("R5,C0,M1,S34..58,B34..45,NM"), a named rule ("Wireworld"), a block rule
+//("Critters") or an elementary rule ("W110")
+func NewWorldWithRule(height, width uint32, rulestring string) (World, error) {
+	if stateRule, ok := ParseNamedRule(rulestring); ok {
+		world := newWorld(height, width, nil, nil, stateRule.Name(), stateRule.States())
+		world.stateRule = stateRule
+		return world, nil
+	}
+	if IsMargolusRule(rulestring) {
+		margolus, err := ParseMargolusRule(rulestring)
+		if err != nil {
+			return World{}, err
+		}
+		world := newWorld(height, width, nil, nil, margolus.String(), 2)
+		world.margolus = &margolus
+		return world, nil
+	}
+	if IsElementaryRule(rulestring) {
+		elementary, err := ParseElementaryRule(rulestring)
+		if err != nil {
+			return World{}, err
+		}
+		world := newWorld(height, width, nil, nil, elementary.String(), 2)
+		world.elementary = &elementary
+		return world, nil
+	}
+	if IsLtLRule(rulestring) {
+		ltl, err := ParseLtLRule(rulestring)
+		if err != nil {
+			return World{}, err
+		}
+		world := newWorld(height, width, nil, nil, ltl.String(), ltl.States)
+		world.ltl = &ltl
+		return world, nil
+	}
+	rule, err := ParseRule(rulestring)
+	if err != nil {
+		return World{}, err
+	}
+	world := newWorld(height, width, nil, nil, rule.String(), rule.States)
+	world.cellRules = newCellRules(rule)
+	world.packed = newPackedGrid(height, width, world.cellRules)
+	return world, nil
+}
+
+func newWorld(height, width uint32, alive, dead map[byte]bool, rule string, states uint32) World {
 	data := make(DataGrid, height)
 	for i, _ := range data {
 		data[i] = make([]uint32, width)
@@ -118,120 +286,82 @@ func NewConwayWorld(height, width uint32) World {
 	for i, _ := range buffer {
 		buffer[i] = make([]uint32, width)
 	}
-	alive, dead := GenerateConwayNeighborsRules()
-	return World{
-		width:             width,
-		height:            height,
-		data:              &data,
-		dataBuffer:        &buffer,
-		aliveRulesMapping: alive,
-		deadRulesMapping:  dead,
-		tick:              0,
+	world := World{
+		width:      width,
+		height:     height,
+		data:       &data,
+		dataBuffer: &buffer,
+		cellRules: cellRules{
+			aliveRulesMapping: alive,
+			deadRulesMapping:  dead,
+			states:            states,
+			neighborhoodMasks: MOORE.NeighborhoodMasks(),
+			colors:            RGBBlend{},
+		},
+		rule: rule,
+		tick: 0,
 	}
+	world.SetWorkers(defaultWorkers(), TILES)
+	return world
 }
 
 func (world *World) GetTick() uint64 {
 	return world.tick
 }
 
-func (world *World) innerWorker(minY, minX, maxY, maxX uint32, blendColors bool, wg *sync.WaitGroup) {
+func (world *World) GetRule() string {
+	return world.rule
+}
+
+//innerWorker returns whether any of the cells changed
+func (world *World) innerWorker(minY, minX, maxY, maxX uint32) bool {
+	changed := false
 	for y := minY; y < maxY; y++ {
 		for x := minX; x < maxX; x++ {
 			alive := isAliveBool((*world.data)[y][x])
-			neighborhood := world.data.InnerNeighborsValue(y, x)
+			neighborhood := world.data.InnerNeighborsValue(y, x) & world.neighborhoodMasks[y&1]
 
 			if alive {
-				world.setNewAliveBufferState(y, x, neighborhood, blendColors)
+				world.setNewAliveBufferState(y, x, world.data, y, x, neighborhood)
 			} else {
-				world.setNewDeadBufferState(y, x, neighborhood, blendColors)
+				world.setNewDeadBufferState(y, x, world.data, y, x, neighborhood)
 			}
+			changed = changed || (*world.dataBuffer)[y][x] != (*world.data)[y][x]
 		}
 	}
-	wg.Done()
+	return changed
 }
 
-func (world *World) PerimeterWorker(blendColors bool, wg *sync.WaitGroup) {
-	y := uint32(0)
-	for x := uint32(1); x < world.width-1; x++ {
-		alive := isAliveBool((*world.data)[y][x])
-		neighborhood := world.data.PerimeterNeighborsValue(N, y, x)
-		if alive {
-			world.setNewAliveBufferState(y, x, neighborhood, blendColors)
-		} else {
-			world.setNewDeadBufferState(y, x, neighborhood, blendColors)
-		}
+//PerimeterWorker returns whether any of the perimeter cells changed
+func (world *World) PerimeterWorker() bool {
+	//the perimeter cells and their neighbors (across any seams) are copied into a 3x3 grid, and evaluated at its center
+	grid := make(DataGrid, 3)
+	for i, _ := range grid {
+		grid[i] = make([]uint32, 3)
 	}
 
-	y = world.height - 1
-	for x := uint32(1); x < world.width-1; x++ {
-		alive := isAliveBool((*world.data)[y][x])
-		neighborhood := world.data.PerimeterNeighborsValue(S, y, x)
-		if alive {
-			world.setNewAliveBufferState(y, x, neighborhood, blendColors)
-		} else {
-			world.setNewDeadBufferState(y, x, neighborhood, blendColors)
-		}
+	changed := false
+	for x := uint32(0); x < world.width; x++ {
+		changed = world.perimeterCellWorker(0, x, &grid) || changed
+		changed = world.perimeterCellWorker(world.height-1, x, &grid) || changed
 	}
-
-	x := world.width - 1
 	for y := uint32(1); y < world.height-1; y++ {
-		alive := isAliveBool((*world.data)[y][x])
-		neighborhood := world.data.PerimeterNeighborsValue(E, y, x)
-		if alive {
-			world.setNewAliveBufferState(y, x, neighborhood, blendColors)
-		} else {
-			world.setNewDeadBufferState(y, x, neighborhood, blendColors)
-		}
-	}
-
-	x = uint32(0)
-	for y := uint32(1); y < world.height-1; y++ {
-		alive := isAliveBool((*world.data)[y][x])
-		neighborhood := world.data.PerimeterNeighborsValue(W, y, x)
-		if alive {
-			world.setNewAliveBufferState(y, x, neighborhood, blendColors)
-		} else {
-			world.setNewDeadBufferState(y, x, neighborhood, blendColors)
-		}
-	}
-
-	//x is already 0
-	y = 0
-	alive := isAliveBool((*world.data)[y][x])
-	neighborhood := world.data.PerimeterNeighborsValue(NW, y, x)
-	if alive {
-		world.setNewAliveBufferState(y, x, neighborhood, blendColors)
-	} else {
-		world.setNewDeadBufferState(y, x, neighborhood, blendColors)
-	}
-
-	x = world.width - 1
-	alive = isAliveBool((*world.data)[y][x])
-	neighborhood = world.data.PerimeterNeighborsValue(NE, y, x)
-	if alive {
-		world.setNewAliveBufferState(y, x, neighborhood, blendColors)
-	} else {
-		world.setNewDeadBufferState(y, x, neighborhood, blendColors)
-	}
-
-	y = world.height - 1
-	alive = isAliveBool((*world.data)[y][x])
-	neighborhood = world.data.PerimeterNeighborsValue(SE, y, x)
-	if alive {
-		world.setNewAliveBufferState(y, x, neighborhood, blendColors)
-	} else {
-		world.setNewDeadBufferState(y, x, neighborhood, blendColors)
+		changed = world.perimeterCellWorker(y, 0, &grid) || changed
+		changed = world.perimeterCellWorker(y, world.width-1, &grid) || changed
 	}
+	return changed
+}
 
-	x = 0
-	alive = isAliveBool((*world.data)[y][x])
-	neighborhood = world.data.PerimeterNeighborsValue(SW, y, x)
+func (world *World) perimeterCellWorker(y, x uint32, grid *DataGrid) bool {
+	world.perimeterNeighborhoodGrid(y, x, grid)
+	alive := isAliveBool((*grid)[1][1])
+	neighborhood := grid.InnerNeighborsValue(1, 1) & world.neighborhoodMasks[y&1]
 	if alive {
-		world.setNewAliveBufferState(y, x, neighborhood, blendColors)
+		world.setNewAliveBufferState(y, x, grid, 1, 1, neighborhood)
 	} else {
-		world.setNewDeadBufferState(y, x, neighborhood, blendColors)
+		world.setNewDeadBufferState(y, x, grid, 1, 1, neighborhood)
 	}
-	wg.Done()
+	return (*world.dataBuffer)[y][x] != (*world.data)[y][x]
 }
 
 func (world *World) swapBuffers() {
@@ -241,83 +371,66 @@ func (world *World) swapBuffers() {
 	world.tick++
 }
 
-//workersSqrt is the square root of the numbers of workers to be used + 1. Aka workersSqrt = 3 means 9+1 workers will
-//be utilized
-func (world *World) Tick(workersSqrt uint32, blendColors bool) {
-
-	//TODO precompute all the dimensions of worker grids
-	//TODO probably also just make the workers once, and keep them idle?
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go world.PerimeterWorker(blendColors, &wg)
-
-	if workersSqrt == 1 {
-		wg.Add(1)
-		go world.innerWorker(1, 1, world.height-1, world.width-1, blendColors, &wg)
+//Tick advances the world by one generation, using the workers set by SetWorkers
+func (world *World) Tick() {
+	world.recordEdits()
+	world.hashEdits()
+	if world.ltl != nil {
+		world.tickLargerThanLife()
+	} else if world.stateRule != nil {
+		world.tickStates()
+	} else if world.margolus != nil {
+		world.tickBlocks()
+	} else if world.elementary != nil {
+		world.tickElementary()
+	} else if world.packed != nil && !world.colors.Blends() {
+		world.tickPacked()
 	} else {
-		divisionsX := make([]uint32, workersSqrt+1)
-		divisionsY := make([]uint32, workersSqrt+1)
-		xStep := world.width / workersSqrt
-		yStep := world.height / workersSqrt
-
-		divisionsY[0] = 1
-		divisionsX[0] = 1
-		//divisionsY[workersSqrt+1] = world.height-1
-		//divisionsX[workersSqrt+1] = world.width-1
-		for i := uint32(1); i < workersSqrt; i++ {
-			divisionsX[i] = xStep * i
-			divisionsY[i] = yStep * i
-		}
-
-		divisionsY[workersSqrt] = world.height - 1
-		divisionsX[workersSqrt] = world.width - 1
-
-		for yi := uint32(0); yi < workersSqrt; yi++ {
-			for xi := uint32(0); xi < workersSqrt; xi++ {
-				//log.Printf("Worker spans [%d, %d] to (%d, %d)", divisionsY[yi], divisionsX[xi], divisionsY[yi+1], divisionsX[xi+1])
-				wg.Add(1)
-				go world.innerWorker(divisionsY[yi], divisionsX[xi], divisionsY[yi+1], divisionsX[xi+1], blendColors, &wg)
-			}
-		}
+		world.tickCells()
 	}
+	world.recordStats()
+	world.recordHistory()
+	world.detectStabilization()
+}
 
-	wg.Wait()
+func (world *World) tickCells() {
+	//only the regions where something changed last tick, or was edited since, are recomputed
+	world.computeDirty()
+	world.workerPool().run(len(world.tiles)+1, func(i int) {
+		if i == 0 {
+			world.changed[len(world.regions)] = world.PerimeterWorker() || world.changed[len(world.regions)]
+		} else {
+			world.tickRegions(i - 1)
+		}
+	})
 	world.swapBuffers()
+	//the clients haven't seen the edits from before the tick either
+	world.changedAll = world.editedAll
+	world.editedAll = false
+	if world.packed != nil {
+		world.packed.synced = false
+	}
 }
 
 func Decay(cell uint32) uint32 {
 	lowerByte := cell & 0x000000FF
 	//log.Printf("%32b\n", lowerByte)
-	if lowerByte == uint32(2) || lowerByte == uint32(1) {
+	if lowerByte <= uint32(2) {
 		//don't kill the cell, just keep it at 1
-		return cell
+		return cell&^ALIVE_NEW | ALIVE_BIT
 	} else {
 		return cell - 2
 	}
 }
 
-func (world *World) setNewAliveBufferState(y, x uint32, neighborhood byte, blendColors bool) {
-	if world.aliveRulesMapping[neighborhood] {
-		if blendColors {
-			(*world.dataBuffer)[y][x] = (*world.data).ExistingCellNeighborsColorBlend((*world.data)[y][x], y, x, neighborhood)
-		} else {
-			(*world.dataBuffer)[y][x] = Decay((*world.dataBuffer)[y][x])
-		}
-	} else {
-		(*world.dataBuffer)[y][x] = 0
-	}
+//src holds the current state of the cell at (srcY, srcX) and its neighbors, which is usually the world itself, but can
+//be a copy of the neighborhood for cells on the perimeter
+func (world *World) setNewAliveBufferState(y, x uint32, src *DataGrid, srcY, srcX uint32, neighborhood byte) {
+	(*world.dataBuffer)[y][x] = world.nextAliveState(src, srcY, srcX, neighborhood)
 }
 
-func (world *World) setNewDeadBufferState(y, x uint32, neighborhood byte, blendColors bool) {
-	if world.deadRulesMapping[neighborhood] {
-		if blendColors {
-			(*world.dataBuffer)[y][x] = (*world.data).NewCellNeighborsColorBlend(y, x, neighborhood)
-		} else {
-			(*world.dataBuffer)[y][x] = (*world.data).NeighborsColorMajority(y, x, neighborhood)
-		}
-	} else {
-		(*world.dataBuffer)[y][x] = DEAD
-	}
+func (world *World) setNewDeadBufferState(y, x uint32, src *DataGrid, srcY, srcX uint32, neighborhood byte) {
+	(*world.dataBuffer)[y][x] = world.nextDeadState(src, srcY, srcX, neighborhood)
 }
 
 func (world *World) ToString() string {
@@ -338,15 +451,22 @@ func (world *World) ToString() string {
 	return buf.String()
 }
 
+//PlaceRLEAtCoords places the RLE with its top-left corner at (y, x). On a bounded world the RLE must fit entirely
+//within the world, but on the other topologies it wraps across the edges
 func (world *World) PlaceRLEAtCoords(rle RLE, y, x, color uint32) bool {
-	if y+rle.height > world.height || x+rle.width > world.width {
+	if world.topology == BOUNDED && (uint64(y)+uint64(rle.height) > uint64(world.height) ||
+		uint64(x)+uint64(rle.width) > uint64(world.width)) {
 		return false
 	}
 
 	for yy := uint32(0); yy < rle.height; yy++ {
 		for xx := uint32(0); xx < rle.width; xx++ {
-			if rle.data[yy][xx] {
-				(*world.data)[y+yy][x+xx] = color | ALIVE_NEW
+			if cell := world.rleCell(rle, yy, xx, color); cell != DEAD {
+				wy, wx, ok := world.wrapCoords(int64(y)+int64(yy), int64(x)+int64(xx))
+				if ok {
+					(*world.data)[wy][wx] = cell
+					world.editedCell(wy, wx)
+				}
 			}
 		}
 	}
@@ -355,10 +475,12 @@ func (world *World) PlaceRLEAtCoords(rle RLE, y, x, color uint32) bool {
 
 func (world *World) MarkAlive(y, x uint32) {
 	(*world.data)[y][x] = FULL
+	world.editedCell(y, x)
 }
 
 func (world *World) MarkAliveColor(y, x uint32, color uint32) {
 	(*world.data)[y][x] = color | ALIVE_NEW
+	world.editedCell(y, x)
 }
 
 func (world *World) Clear() {
@@ -367,6 +489,7 @@ func (world *World) Clear() {
 			(*world.data)[y][x] = DEAD
 		}
 	}
+	world.edited()
 }
 
 const (
@@ -374,6 +497,31 @@ const (
 	MARK_CELL    int = 2
 	PLACE_RLE    int = 3
 	CLEAR_BOARD  int = 4
+	STEP_BACK    int = 5
+	STEP_FORWARD int = 6
+	JUMP_TO_TICK int = 7
+	//fills the region (Height by Width, with its top-left corner at Y and X) with a soup, using the seed in Info
+	RANDOMIZE_REGION int = 8
+	//sets the colors (in Colors) that the stats count live cells by
+	STATS_COLORS int = 9
+	//sets the cell at Y and X to State, in Color if the rule's states don't have their own colors
+	PAINT_STATE int = 10
+	//switches the direction a world with a reversible block rule runs in
+	TOGGLE_REVERSE int = 11
+	//resizes the world to Height by Width, keeping the anchor named in Info in place
+	RESIZE int = 12
+	//copy or cut the region (Height by Width, with its top-left corner at Y and X) into the clipboard of the Player
+	COPY_REGION int = 13
+	CUT_REGION  int = 14
+	//pastes the clipboard of the Player at Y and X, merged in the mode named in Info
+	PASTE int = 15
+	//moves the region to ToY and ToX, merged in the mode named in Info
+	MOVE_REGION  int = 16
+	CLEAR_REGION int = 17
+	//forgets the clipboard of the Player, who disconnected
+	PLAYER_LEFT int = 18
+	//replaces the world with its fork from Tick
+	FORK int = 19
 )
 
 type SimulatorMessage struct {
@@ -381,6 +529,20 @@ type SimulatorMessage struct {
 	X     uint32
 	Y     uint32
 	Color uint32
+	Tick  uint64
+
+	Height   uint32
+	Width    uint32
+	Density  float64
+	Symmetry string
+	Colors   []uint32
+	State    uint32
+	Player   uint32
+	ToY      uint32
+	ToX      uint32
+	//the origin of the frame that Y and X are relative to, which only unbounded worlds move
+	OriginY int64
+	OriginX int64
 
 	Info string
 }
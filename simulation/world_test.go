@@ -1,9 +1,7 @@
 package simulation
 
 import (
-	"log"
 	"testing"
-	"time"
 )
 
 func TestDataGrid_NeighborsValue(t *testing.T) {
@@ -12,56 +10,53 @@ func TestDataGrid_NeighborsValue(t *testing.T) {
 	grid[1] = make([]uint32, 3)
 	grid[2] = make([]uint32, 3)
 
-	grid[0][0] = ALIVE
-	if grid.InnerNeighborsValue(1, 1) != 0b000_00_001 {
+	grid[0][0] = ALIVE_BIT
+	if grid.InnerNeighborsValue(1, 1) != 0b0000_0001 {
 		t.Fail()
 	}
 
-	grid[2][2] = ALIVE
-	if grid.InnerNeighborsValue(1, 1) != 0b100_00_001 {
+	grid[2][2] = ALIVE_BIT
+	if grid.InnerNeighborsValue(1, 1) != 0b0001_0001 {
 		t.Fail()
 	}
 
-	grid[0][2] = ALIVE
-	if grid.InnerNeighborsValue(1, 1) != 0b100_00_101 {
+	grid[0][2] = ALIVE_BIT
+	if grid.InnerNeighborsValue(1, 1) != 0b0001_0101 {
 		t.Fail()
 	}
 
-	grid[2][0] = ALIVE
-	if grid.InnerNeighborsValue(1, 1) != 0b101_00_101 {
+	grid[2][0] = ALIVE_BIT
+	if grid.InnerNeighborsValue(1, 1) != 0b0101_0101 {
 		t.Fail()
 	}
 
-	grid[0][1] = ALIVE
-	if grid.InnerNeighborsValue(1, 1) != 0b101_00_111 {
+	grid[0][1] = ALIVE_BIT
+	if grid.InnerNeighborsValue(1, 1) != 0b0101_0111 {
 		t.Fail()
 	}
 
-	grid[2][1] = ALIVE
-	if grid.InnerNeighborsValue(1, 1) != 0b111_00_111 {
+	grid[2][1] = ALIVE_BIT
+	if grid.InnerNeighborsValue(1, 1) != 0b0111_0111 {
 		t.Fail()
 	}
 
-	grid[1][0] = ALIVE
-	if grid.InnerNeighborsValue(1, 1) != 0b111_01_111 {
+	grid[1][0] = ALIVE_BIT
+	if grid.InnerNeighborsValue(1, 1) != 0b1111_0111 {
 		t.Fail()
 	}
 
-	grid[1][2] = ALIVE
-	if grid.InnerNeighborsValue(1, 1) != 0b111_11_111 {
+	grid[1][2] = ALIVE_BIT
+	if grid.InnerNeighborsValue(1, 1) != 0b1111_1111 {
 		t.Fail()
 	}
 }
 
-func TestWorld_Tick(t *testing.T) {
-	const Iterations = 100
-	world := NewConwayWorld(10000, 10000)
-	now := time.Now().UnixNano()
-	for i := 0; i < Iterations; i++ {
+func BenchmarkWorld_Tick(b *testing.B) {
+	world := NewConwayWorld(1000, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
 		world.Tick()
 	}
-	end := time.Now().UnixNano() - now
-	log.Printf("Took %fms to complete %d iterations on a 10k^2 grid", float64(end)/1_000_000.0, Iterations)
 }
 
 func TestWorld_Tick2(t *testing.T) {
@@ -73,7 +68,7 @@ func TestWorld_Tick2(t *testing.T) {
 
 	world.Tick()
 
-	if (*world.data)[0][0]&ALIVE > 0 || (*world.data)[9][9]&ALIVE > 0 || (*world.data)[0][9]&ALIVE > 0 || (*world.data)[9][0]&ALIVE > 0 {
+	if (*world.data)[0][0]&ALIVE_BIT > 0 || (*world.data)[9][9]&ALIVE_BIT > 0 || (*world.data)[0][9]&ALIVE_BIT > 0 || (*world.data)[9][0]&ALIVE_BIT > 0 {
 		t.Fail()
 	}
 
@@ -81,7 +76,7 @@ func TestWorld_Tick2(t *testing.T) {
 	world.MarkAlive(0, 2)
 	world.MarkAlive(0, 3)
 	world.Tick()
-	if (*world.data)[0][2]&ALIVE == 0 || (*world.data)[1][2]&ALIVE == 0 {
+	if (*world.data)[0][2]&ALIVE_BIT == 0 || (*world.data)[1][2]&ALIVE_BIT == 0 {
 		t.Fail()
 	}
 }
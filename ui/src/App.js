@@ -12,6 +12,45 @@ const REGISTERED = 2;
 
 const DEBUG_DONT_REGISTER_FOR_DATA = false;
 
+const ALIVE = 0x00000001;
+
+//expandCells undoes the run-length encoding of the dead cells in world data: entries with the alive bit set are cells,
+//and every other entry is a count of dead cells, shifted left by 1
+function expandCells(data, count) {
+    let cells = new Uint32Array(count);
+    let i = 0;
+    for (let j = 0; j < data.length && i < count; j++) {
+        if ((data[j] & ALIVE) === 1) {
+            cells[i] = data[j];
+            i++;
+        } else {
+            i += data[j] >>> 1;
+        }
+    }
+    return cells;
+}
+
+//patchCells copies the cells of a partial frame into a copy of the board. Each changed region is 4 numbers (its y, x,
+//height and width), and the cells of the regions come one region after the other, row by row
+function patchCells(board, width, data, regions) {
+    let count = 0;
+    for (let r = 0; r < regions.length; r += 4) {
+        count += regions[r + 2] * regions[r + 3];
+    }
+    let cells = expandCells(data, count);
+    let patched = board.slice();
+    let i = 0;
+    for (let r = 0; r < regions.length; r += 4) {
+        for (let y = regions[r]; y < regions[r] + regions[r + 2]; y++) {
+            for (let x = regions[r + 1]; x < regions[r + 1] + regions[r + 3]; x++) {
+                patched[y * width + x] = cells[i];
+                i++;
+            }
+        }
+    }
+    return patched;
+}
+
 let BASE_URL = process.env.REACT_APP_SERVICE_URL;
 if (!BASE_URL || BASE_URL === "") {
     console.log("REACT_APP_SERVICE_URL not provided; defaulting to localhost:5000")
@@ -38,6 +77,11 @@ class App extends Component {
             boardTick: 0,
             boardWidth: 0,
             boardHeight: 0,
+            //where the top-left cell of the board is in the world, which unbounded worlds move as they evolve
+            boardOriginY: 0,
+            boardOriginX: 0,
+            //whether the cells hold the ID of the player that owns them instead of a color
+            ownership: false,
             totalCanvasWidth: 0,
             totalCanvasHeight: 0,
             paused: false,
@@ -80,21 +124,42 @@ class App extends Component {
                 switch (message.getType()) {
                     case Messages.MessageType.WORLD_DATA:
                         let WorldMessage = Messages.WorldData.deserializeBinary(message.getContent())
-                        if (WorldMessage.getHeight() !== 0 && WorldMessage.getWidth() !== 0) {
-                            this.setState({
-                                boardWidth: WorldMessage.getWidth(),
-                                boardHeight: WorldMessage.getHeight(),
-                                boardData: WorldMessage.getDataList(),
+                        //the board is updated from the last one, as partial frames only have the regions that changed
+                        this.setState(state => {
+                            let width = state.boardWidth;
+                            let height = state.boardHeight;
+                            let originY = state.boardOriginY;
+                            let originX = state.boardOriginX;
+                            let ownership = state.ownership;
+                            if (WorldMessage.getHeight() !== 0 && WorldMessage.getWidth() !== 0) {
+                                width = WorldMessage.getWidth();
+                                height = WorldMessage.getHeight();
+                                originY = WorldMessage.getOriginY();
+                                originX = WorldMessage.getOriginX();
+                                ownership = WorldMessage.getOwnership();
+                            }
+                            let boardData;
+                            if (WorldMessage.getPartial()) {
+                                if (!state.boardData || state.boardData.length !== width * height) {
+                                    //there's no full frame to apply the changes to yet
+                                    return null;
+                                }
+                                boardData = patchCells(state.boardData, width, WorldMessage.getDataList(),
+                                    WorldMessage.getChangedRegionsList());
+                            } else {
+                                boardData = expandCells(WorldMessage.getDataList(), width * height);
+                            }
+                            return {
+                                boardWidth: width,
+                                boardHeight: height,
+                                boardOriginY: originY,
+                                boardOriginX: originX,
+                                ownership: ownership,
+                                boardData: boardData,
                                 boardTick: WorldMessage.getTick(),
                                 paused: WorldMessage.getPaused()
-                            })
-                        } else {
-                            this.setState({
-                                boardData: WorldMessage.getDataList(),
-                                boardTick: WorldMessage.getTick(),
-                                paused: WorldMessage.getPaused()
-                            })
-                        }
+                            };
+                        })
 
                         break;
                     case Messages.MessageType.REGISTER:
@@ -230,6 +295,9 @@ class App extends Component {
             cmdMsg.setX(cellX)
             cmdMsg.setY(cellY)
         }
+        //the cell is relative to the board the player clicked on
+        cmdMsg.setOriginY(this.state.boardOriginY)
+        cmdMsg.setOriginX(this.state.boardOriginX)
         let innerBytes = cmdMsg.serializeBinary()
         let msg = new Messages.Message();
         msg.setType(Messages.MessageType.COMMAND);
@@ -315,6 +383,8 @@ class App extends Component {
                                       canvasHeight={this.state.boardHeight + this.state.boardHeight}
                                       paused={this.state.paused}
                                       currentRLE={this.state.currentRLE}
+                                      ownership={this.state.ownership}
+                                      players={this.state.playersOnline}
                                       color={this.state.color}/>
                             </div>
                             : this.state.gameState !== UNCONNECTED ? <div>
@@ -5,6 +5,9 @@ const ALIVE = 0x00000001;
 
 const bgColor = "#000000";
 
+//cells owned by a player who's left are drawn in this (24bit) color
+const unknownOwnerColor = 0x808080;
+
 export default class Game extends Component {
     lastTime;
 
@@ -33,6 +36,9 @@ export default class Game extends Component {
     }
 
     equal(arr1, arr2) {
+        if (!arr1 || !arr2) {
+            return arr1 === arr2
+        }
         if (arr1.length !== arr2.length) {
             return false
         }
@@ -72,7 +78,10 @@ export default class Game extends Component {
             && (!prevProps.currentRLE || prevProps.currentRLE.getName() !== this.props.currentRLE.getName())) {
             this.setState({currentRLE: this.props.currentRLE})
         }
-
+        if (!this.props.boardData) {
+            //the first full frame hasn't come yet
+            return;
+        }
 
         if (this.props.paused !== prevProps.paused
             || this.props.tick !== prevProps.tick
@@ -81,7 +90,7 @@ export default class Game extends Component {
                 || this.state.mouseCellY !== prevState.mouseCellY
                 || this.state.mouseInCanvas !== prevState.mouseInCanvas) && this.state.currentRLE && this.props.paused)) {
             //console.log("Time since last data: " + (Date.now()-this.lastTime))
-            console.log("Updating canvas, total data array size received: " + this.props.boardData.length * 4 + " bytes")
+            console.log("Updating canvas of " + this.props.boardData.length + " cells")
             this.lastTime = Date.now();
             const canvas = this.canvasRef.current;
             if (this.props.paused !== prevProps.paused) {
@@ -95,39 +104,33 @@ export default class Game extends Component {
             context.fillRect(0, 0, canvas.width, canvas.height);
             let cWidth = canvas.width / this.props.width;
             let cHeight = canvas.height / this.props.height;
-            let y = 0;
-            let x = 0;
+            //in ownership mode, the cells hold the ID of their owner instead of a color, so they're drawn in theirs
+            let ownerColors = null;
+            if (this.props.ownership) {
+                ownerColors = new Map();
+                if (this.props.players) {
+                    for (let player of this.props.players) {
+                        ownerColors.set(player.getId(), player.getColor() >>> 8);
+                    }
+                }
+            }
+            //the board has every cell, row by row, with the dead ones left at 0
             for (let i = 0; i < this.props.boardData.length; i++) {
                 let cell = this.props.boardData[i];
                 if ((cell & ALIVE) === 1) {
-                    //this is so dumb; fighting JS' unsigned integer stupidity
-                    let r = ((cell >> 8) & (0x000000FF << 16)) >> 16;
-                    let g = ((cell >> 8) & (0x000000FF << 8)) >> 8;
-                    let b = (cell >> 8) & 0x000000FF;
+                    let y = Math.floor(i / this.props.width);
+                    let x = i % this.props.width;
+                    let color = cell >>> 8;
+                    if (ownerColors) {
+                        color = ownerColors.has(color) ? ownerColors.get(color) : unknownOwnerColor;
+                    }
+                    let r = (color >> 16) & 0xFF;
+                    let g = (color >> 8) & 0xFF;
+                    let b = color & 0xFF;
                     let aliveness = ((cell & 0x000000FF)+128.0) / 255.0;
                     context.fillStyle = 'rgba(' + r + ', ' + g + ',' + b + ',' + aliveness + ')';
                     //console.log('rgba(' + r + ', ' + g + ',' + b + ',' + aliveness + ')')
                     context.fillRect(x * cWidth, y * cHeight, cWidth - 1, cHeight - 1);
-                    x++;
-                    if (x > this.props.width-1) {
-                        y++;
-                        x=0;
-                    }
-                } else {
-                    //get all the bits not associated with aliveness (dead cells don't need colors)
-                    let rleDeadCells = cell >> 1
-                    //console.log(rleDeadCells)
-                    if (rleDeadCells > 0) {
-                        for (; x + rleDeadCells > this.props.width-1; ) {
-                            //subtract how many cells it took to reach the end of the row
-                            let cellsSkipped = this.props.width - x;
-                            rleDeadCells -= cellsSkipped;
-                            x=0;
-                            y++;
-                        }
-
-                        x += rleDeadCells;
-                    }
                 }
             }
             context.fillStyle = bgColor;
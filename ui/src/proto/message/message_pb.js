@@ -13,7 +13,10 @@ var jspb = require('google-protobuf');
 var goog = jspb;
 var global = Function('return this')();
 
+goog.exportSymbol('proto.message.Census', null, global);
+goog.exportSymbol('proto.message.CensusEntry', null, global);
 goog.exportSymbol('proto.message.Chat', null, global);
+goog.exportSymbol('proto.message.ColorCount', null, global);
 goog.exportSymbol('proto.message.Command', null, global);
 goog.exportSymbol('proto.message.CommandType', null, global);
 goog.exportSymbol('proto.message.Message', null, global);
@@ -24,6 +27,9 @@ goog.exportSymbol('proto.message.RLEs', null, global);
 goog.exportSymbol('proto.message.Response', null, global);
 goog.exportSymbol('proto.message.ResponseCode', null, global);
 goog.exportSymbol('proto.message.ServerData', null, global);
+goog.exportSymbol('proto.message.Stabilization', null, global);
+goog.exportSymbol('proto.message.Stats', null, global);
+goog.exportSymbol('proto.message.TickStats', null, global);
 goog.exportSymbol('proto.message.WorldData', null, global);
 /**
  * Generated by JsPbCodeGenerator.
@@ -172,6 +178,132 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.message.Chat.displayName = 'proto.message.Chat';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.message.Stabilization = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.message.Stabilization, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.message.Stabilization.displayName = 'proto.message.Stabilization';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.message.Census = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.message.Census.repeatedFields_, null);
+};
+goog.inherits(proto.message.Census, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.message.Census.displayName = 'proto.message.Census';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.message.CensusEntry = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.message.CensusEntry, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.message.CensusEntry.displayName = 'proto.message.CensusEntry';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.message.Stats = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.message.Stats.repeatedFields_, null);
+};
+goog.inherits(proto.message.Stats, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.message.Stats.displayName = 'proto.message.Stats';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.message.TickStats = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.message.TickStats.repeatedFields_, null);
+};
+goog.inherits(proto.message.TickStats, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.message.TickStats.displayName = 'proto.message.TickStats';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.message.ColorCount = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.message.ColorCount, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.message.ColorCount.displayName = 'proto.message.ColorCount';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -431,7 +563,8 @@ proto.message.Player.prototype.toObject = function(opt_includeInstance) {
 proto.message.Player.toObject = function(includeInstance, msg) {
   var f, obj = {
     name: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    color: jspb.Message.getFieldWithDefault(msg, 2, 0)
+    color: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    id: jspb.Message.getFieldWithDefault(msg, 3, 0)
   };
 
   if (includeInstance) {
@@ -476,6 +609,10 @@ proto.message.Player.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {number} */ (reader.readFixed32());
       msg.setColor(value);
       break;
+    case 3:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setId(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -519,6 +656,13 @@ proto.message.Player.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getId();
+  if (f !== 0) {
+    writer.writeUint32(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -558,13 +702,31 @@ proto.message.Player.prototype.setColor = function(value) {
 };
 
 
+/**
+ * optional uint32 id = 3;
+ * @return {number}
+ */
+proto.message.Player.prototype.getId = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Player} returns this
+ */
+proto.message.Player.prototype.setId = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
  * @private {!Array<number>}
  * @const
  */
-proto.message.WorldData.repeatedFields_ = [1];
+proto.message.WorldData.repeatedFields_ = [1,14,16];
 
 
 
@@ -601,7 +763,19 @@ proto.message.WorldData.toObject = function(includeInstance, msg) {
     tick: jspb.Message.getFieldWithDefault(msg, 2, 0),
     width: jspb.Message.getFieldWithDefault(msg, 3, 0),
     height: jspb.Message.getFieldWithDefault(msg, 4, 0),
-    paused: jspb.Message.getBooleanFieldWithDefault(msg, 5, false)
+    paused: jspb.Message.getBooleanFieldWithDefault(msg, 5, false),
+    rule: jspb.Message.getFieldWithDefault(msg, 6, ""),
+    states: jspb.Message.getFieldWithDefault(msg, 7, 0),
+    originY: jspb.Message.getFieldWithDefault(msg, 8, 0),
+    originX: jspb.Message.getFieldWithDefault(msg, 9, 0),
+    historyOldest: jspb.Message.getFieldWithDefault(msg, 10, 0),
+    historyNewest: jspb.Message.getFieldWithDefault(msg, 11, 0),
+    ownership: jspb.Message.getBooleanFieldWithDefault(msg, 12, false),
+    colorRule: jspb.Message.getFieldWithDefault(msg, 13, ""),
+    stateColorsList: (f = jspb.Message.getRepeatedField(msg, 14)) == null ? undefined : f,
+    reversible: jspb.Message.getBooleanFieldWithDefault(msg, 15, false),
+    changedRegionsList: (f = jspb.Message.getRepeatedField(msg, 16)) == null ? undefined : f,
+    partial: jspb.Message.getBooleanFieldWithDefault(msg, 17, false)
   };
 
   if (includeInstance) {
@@ -658,6 +832,54 @@ proto.message.WorldData.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setPaused(value);
       break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setRule(value);
+      break;
+    case 7:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setStates(value);
+      break;
+    case 8:
+      var value = /** @type {number} */ (reader.readSint64());
+      msg.setOriginY(value);
+      break;
+    case 9:
+      var value = /** @type {number} */ (reader.readSint64());
+      msg.setOriginX(value);
+      break;
+    case 10:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setHistoryOldest(value);
+      break;
+    case 11:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setHistoryNewest(value);
+      break;
+    case 12:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setOwnership(value);
+      break;
+    case 13:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setColorRule(value);
+      break;
+    case 14:
+      var value = /** @type {!Array<number>} */ (reader.readPackedFixed32());
+      msg.setStateColorsList(value);
+      break;
+    case 15:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setReversible(value);
+      break;
+    case 16:
+      var value = /** @type {!Array<number>} */ (reader.readPackedUint32());
+      msg.setChangedRegionsList(value);
+      break;
+    case 17:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setPartial(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -722,6 +944,90 @@ proto.message.WorldData.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getRule();
+  if (f.length > 0) {
+    writer.writeString(
+      6,
+      f
+    );
+  }
+  f = message.getStates();
+  if (f !== 0) {
+    writer.writeUint32(
+      7,
+      f
+    );
+  }
+  f = message.getOriginY();
+  if (f !== 0) {
+    writer.writeSint64(
+      8,
+      f
+    );
+  }
+  f = message.getOriginX();
+  if (f !== 0) {
+    writer.writeSint64(
+      9,
+      f
+    );
+  }
+  f = message.getHistoryOldest();
+  if (f !== 0) {
+    writer.writeUint64(
+      10,
+      f
+    );
+  }
+  f = message.getHistoryNewest();
+  if (f !== 0) {
+    writer.writeUint64(
+      11,
+      f
+    );
+  }
+  f = message.getOwnership();
+  if (f) {
+    writer.writeBool(
+      12,
+      f
+    );
+  }
+  f = message.getColorRule();
+  if (f.length > 0) {
+    writer.writeString(
+      13,
+      f
+    );
+  }
+  f = message.getStateColorsList();
+  if (f.length > 0) {
+    writer.writePackedFixed32(
+      14,
+      f
+    );
+  }
+  f = message.getReversible();
+  if (f) {
+    writer.writeBool(
+      15,
+      f
+    );
+  }
+  f = message.getChangedRegionsList();
+  if (f.length > 0) {
+    writer.writePackedUint32(
+      16,
+      f
+    );
+  }
+  f = message.getPartial();
+  if (f) {
+    writer.writeBool(
+      17,
+      f
+    );
+  }
 };
 
 
@@ -834,64 +1140,318 @@ proto.message.WorldData.prototype.setPaused = function(value) {
 };
 
 
-
 /**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
+ * optional string rule = 6;
+ * @return {string}
  */
-proto.message.ServerData.repeatedFields_ = [1];
-
+proto.message.WorldData.prototype.getRule = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+};
 
 
-if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * Creates an object representation of this proto.
- * Field names that are reserved in JavaScript and will be renamed to pb_name.
- * Optional fields that are not set will be set to undefined.
- * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
- * For the list of reserved names please see:
- *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
- * @param {boolean=} opt_includeInstance Deprecated. whether to include the
- *     JSPB instance for transitional soy proto support:
- *     http://goto/soy-param-migration
- * @return {!Object}
+ * @param {string} value
+ * @return {!proto.message.WorldData} returns this
  */
-proto.message.ServerData.prototype.toObject = function(opt_includeInstance) {
-  return proto.message.ServerData.toObject(opt_includeInstance, this);
+proto.message.WorldData.prototype.setRule = function(value) {
+  return jspb.Message.setProto3StringField(this, 6, value);
 };
 
 
 /**
- * Static version of the {@see toObject} method.
- * @param {boolean|undefined} includeInstance Deprecated. Whether to include
- *     the JSPB instance for transitional soy proto support:
- *     http://goto/soy-param-migration
- * @param {!proto.message.ServerData} msg The msg instance to transform.
- * @return {!Object}
- * @suppress {unusedLocalVariables} f is only used for nested messages
+ * optional uint32 states = 7;
+ * @return {number}
  */
-proto.message.ServerData.toObject = function(includeInstance, msg) {
-  var f, obj = {
-    playersList: jspb.Message.toObjectList(msg.getPlayersList(),
-    proto.message.Player.toObject, includeInstance)
-  };
-
-  if (includeInstance) {
-    obj.$jspbMessageInstance = msg;
-  }
-  return obj;
+proto.message.WorldData.prototype.getStates = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
 };
-}
 
 
 /**
- * Deserializes binary data (in protobuf wire format).
- * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.message.ServerData}
+ * @param {number} value
+ * @return {!proto.message.WorldData} returns this
  */
-proto.message.ServerData.deserializeBinary = function(bytes) {
-  var reader = new jspb.BinaryReader(bytes);
+proto.message.WorldData.prototype.setStates = function(value) {
+  return jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
+/**
+ * optional sint64 origin_y = 8;
+ * @return {number}
+ */
+proto.message.WorldData.prototype.getOriginY = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 8, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setOriginY = function(value) {
+  return jspb.Message.setProto3IntField(this, 8, value);
+};
+
+
+/**
+ * optional sint64 origin_x = 9;
+ * @return {number}
+ */
+proto.message.WorldData.prototype.getOriginX = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 9, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setOriginX = function(value) {
+  return jspb.Message.setProto3IntField(this, 9, value);
+};
+
+
+/**
+ * optional uint64 history_oldest = 10;
+ * @return {number}
+ */
+proto.message.WorldData.prototype.getHistoryOldest = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 10, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setHistoryOldest = function(value) {
+  return jspb.Message.setProto3IntField(this, 10, value);
+};
+
+
+/**
+ * optional uint64 history_newest = 11;
+ * @return {number}
+ */
+proto.message.WorldData.prototype.getHistoryNewest = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 11, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setHistoryNewest = function(value) {
+  return jspb.Message.setProto3IntField(this, 11, value);
+};
+
+
+/**
+ * optional bool ownership = 12;
+ * @return {boolean}
+ */
+proto.message.WorldData.prototype.getOwnership = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 12, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setOwnership = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 12, value);
+};
+
+
+/**
+ * optional string color_rule = 13;
+ * @return {string}
+ */
+proto.message.WorldData.prototype.getColorRule = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 13, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setColorRule = function(value) {
+  return jspb.Message.setProto3StringField(this, 13, value);
+};
+
+
+/**
+ * repeated fixed32 state_colors = 14;
+ * @return {!Array<number>}
+ */
+proto.message.WorldData.prototype.getStateColorsList = function() {
+  return /** @type {!Array<number>} */ (jspb.Message.getRepeatedField(this, 14));
+};
+
+
+/**
+ * @param {!Array<number>} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setStateColorsList = function(value) {
+  return jspb.Message.setField(this, 14, value || []);
+};
+
+
+/**
+ * @param {number} value
+ * @param {number=} opt_index
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.addStateColors = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 14, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.clearStateColorsList = function() {
+  return this.setStateColorsList([]);
+};
+
+
+/**
+ * optional bool reversible = 15;
+ * @return {boolean}
+ */
+proto.message.WorldData.prototype.getReversible = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 15, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setReversible = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 15, value);
+};
+
+
+/**
+ * repeated uint32 changed_regions = 16;
+ * @return {!Array<number>}
+ */
+proto.message.WorldData.prototype.getChangedRegionsList = function() {
+  return /** @type {!Array<number>} */ (jspb.Message.getRepeatedField(this, 16));
+};
+
+
+/**
+ * @param {!Array<number>} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setChangedRegionsList = function(value) {
+  return jspb.Message.setField(this, 16, value || []);
+};
+
+
+/**
+ * @param {number} value
+ * @param {number=} opt_index
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.addChangedRegions = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 16, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.clearChangedRegionsList = function() {
+  return this.setChangedRegionsList([]);
+};
+
+
+/**
+ * optional bool partial = 17;
+ * @return {boolean}
+ */
+proto.message.WorldData.prototype.getPartial = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 17, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.message.WorldData} returns this
+ */
+proto.message.WorldData.prototype.setPartial = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 17, value);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.message.ServerData.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.message.ServerData.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.ServerData.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.message.ServerData} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.ServerData.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    playersList: jspb.Message.toObjectList(msg.getPlayersList(),
+    proto.message.Player.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.message.ServerData}
+ */
+proto.message.ServerData.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
   var msg = new proto.message.ServerData;
   return proto.message.ServerData.deserializeBinaryFromReader(msg, reader);
 };
@@ -1029,7 +1589,18 @@ proto.message.Command.toObject = function(includeInstance, msg) {
     type: jspb.Message.getFieldWithDefault(msg, 1, 0),
     x: jspb.Message.getFieldWithDefault(msg, 2, 0),
     y: jspb.Message.getFieldWithDefault(msg, 3, 0),
-    text: jspb.Message.getFieldWithDefault(msg, 4, "")
+    text: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    tick: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    width: jspb.Message.getFieldWithDefault(msg, 6, 0),
+    height: jspb.Message.getFieldWithDefault(msg, 7, 0),
+    density: jspb.Message.getFloatingPointFieldWithDefault(msg, 8, 0.0),
+    symmetry: jspb.Message.getFieldWithDefault(msg, 9, ""),
+    state: jspb.Message.getFieldWithDefault(msg, 10, 0),
+    adminKey: jspb.Message.getFieldWithDefault(msg, 11, ""),
+    toX: jspb.Message.getFieldWithDefault(msg, 12, 0),
+    toY: jspb.Message.getFieldWithDefault(msg, 13, 0),
+    originY: jspb.Message.getFieldWithDefault(msg, 14, 0),
+    originX: jspb.Message.getFieldWithDefault(msg, 15, 0)
   };
 
   if (includeInstance) {
@@ -1082,6 +1653,50 @@ proto.message.Command.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {string} */ (reader.readString());
       msg.setText(value);
       break;
+    case 5:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setTick(value);
+      break;
+    case 6:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setWidth(value);
+      break;
+    case 7:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setHeight(value);
+      break;
+    case 8:
+      var value = /** @type {number} */ (reader.readFloat());
+      msg.setDensity(value);
+      break;
+    case 9:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setSymmetry(value);
+      break;
+    case 10:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setState(value);
+      break;
+    case 11:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setAdminKey(value);
+      break;
+    case 12:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setToX(value);
+      break;
+    case 13:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setToY(value);
+      break;
+    case 14:
+      var value = /** @type {number} */ (reader.readSint64());
+      msg.setOriginY(value);
+      break;
+    case 15:
+      var value = /** @type {number} */ (reader.readSint64());
+      msg.setOriginX(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1139,18 +1754,95 @@ proto.message.Command.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
-};
-
-
-/**
- * optional CommandType type = 1;
- * @return {!proto.message.CommandType}
- */
-proto.message.Command.prototype.getType = function() {
-  return /** @type {!proto.message.CommandType} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
-};
-
-
+  f = message.getTick();
+  if (f !== 0) {
+    writer.writeUint64(
+      5,
+      f
+    );
+  }
+  f = message.getWidth();
+  if (f !== 0) {
+    writer.writeUint32(
+      6,
+      f
+    );
+  }
+  f = message.getHeight();
+  if (f !== 0) {
+    writer.writeUint32(
+      7,
+      f
+    );
+  }
+  f = message.getDensity();
+  if (f !== 0.0) {
+    writer.writeFloat(
+      8,
+      f
+    );
+  }
+  f = message.getSymmetry();
+  if (f.length > 0) {
+    writer.writeString(
+      9,
+      f
+    );
+  }
+  f = message.getState();
+  if (f !== 0) {
+    writer.writeUint32(
+      10,
+      f
+    );
+  }
+  f = message.getAdminKey();
+  if (f.length > 0) {
+    writer.writeString(
+      11,
+      f
+    );
+  }
+  f = message.getToX();
+  if (f !== 0) {
+    writer.writeUint32(
+      12,
+      f
+    );
+  }
+  f = message.getToY();
+  if (f !== 0) {
+    writer.writeUint32(
+      13,
+      f
+    );
+  }
+  f = message.getOriginY();
+  if (f !== 0) {
+    writer.writeSint64(
+      14,
+      f
+    );
+  }
+  f = message.getOriginX();
+  if (f !== 0) {
+    writer.writeSint64(
+      15,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional CommandType type = 1;
+ * @return {!proto.message.CommandType}
+ */
+proto.message.Command.prototype.getType = function() {
+  return /** @type {!proto.message.CommandType} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
 /**
  * @param {!proto.message.CommandType} value
  * @return {!proto.message.Command} returns this
@@ -1170,51 +1862,1537 @@ proto.message.Command.prototype.getX = function() {
 
 
 /**
- * @param {number} value
- * @return {!proto.message.Command} returns this
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setX = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional uint32 y = 3;
+ * @return {number}
+ */
+proto.message.Command.prototype.getY = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setY = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional string text = 4;
+ * @return {string}
+ */
+proto.message.Command.prototype.getText = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setText = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional uint64 tick = 5;
+ * @return {number}
+ */
+proto.message.Command.prototype.getTick = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setTick = function(value) {
+  return jspb.Message.setProto3IntField(this, 5, value);
+};
+
+
+/**
+ * optional uint32 width = 6;
+ * @return {number}
+ */
+proto.message.Command.prototype.getWidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setWidth = function(value) {
+  return jspb.Message.setProto3IntField(this, 6, value);
+};
+
+
+/**
+ * optional uint32 height = 7;
+ * @return {number}
+ */
+proto.message.Command.prototype.getHeight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setHeight = function(value) {
+  return jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
+/**
+ * optional float density = 8;
+ * @return {number}
+ */
+proto.message.Command.prototype.getDensity = function() {
+  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 8, 0.0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setDensity = function(value) {
+  return jspb.Message.setProto3FloatField(this, 8, value);
+};
+
+
+/**
+ * optional string symmetry = 9;
+ * @return {string}
+ */
+proto.message.Command.prototype.getSymmetry = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 9, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setSymmetry = function(value) {
+  return jspb.Message.setProto3StringField(this, 9, value);
+};
+
+
+/**
+ * optional uint32 state = 10;
+ * @return {number}
+ */
+proto.message.Command.prototype.getState = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 10, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setState = function(value) {
+  return jspb.Message.setProto3IntField(this, 10, value);
+};
+
+
+/**
+ * optional string admin_key = 11;
+ * @return {string}
+ */
+proto.message.Command.prototype.getAdminKey = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 11, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setAdminKey = function(value) {
+  return jspb.Message.setProto3StringField(this, 11, value);
+};
+
+
+/**
+ * optional uint32 to_x = 12;
+ * @return {number}
+ */
+proto.message.Command.prototype.getToX = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 12, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setToX = function(value) {
+  return jspb.Message.setProto3IntField(this, 12, value);
+};
+
+
+/**
+ * optional uint32 to_y = 13;
+ * @return {number}
+ */
+proto.message.Command.prototype.getToY = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 13, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setToY = function(value) {
+  return jspb.Message.setProto3IntField(this, 13, value);
+};
+
+
+/**
+ * optional sint64 origin_y = 14;
+ * @return {number}
+ */
+proto.message.Command.prototype.getOriginY = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 14, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setOriginY = function(value) {
+  return jspb.Message.setProto3IntField(this, 14, value);
+};
+
+
+/**
+ * optional sint64 origin_x = 15;
+ * @return {number}
+ */
+proto.message.Command.prototype.getOriginX = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 15, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Command} returns this
+ */
+proto.message.Command.prototype.setOriginX = function(value) {
+  return jspb.Message.setProto3IntField(this, 15, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.message.Response.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.Response.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.message.Response} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Response.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    code: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    text: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.message.Response}
+ */
+proto.message.Response.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.message.Response;
+  return proto.message.Response.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.message.Response} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.message.Response}
+ */
+proto.message.Response.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {!proto.message.ResponseCode} */ (reader.readEnum());
+      msg.setCode(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setText(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.message.Response.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.message.Response.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.message.Response} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Response.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getCode();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      1,
+      f
+    );
+  }
+  f = message.getText();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional ResponseCode code = 1;
+ * @return {!proto.message.ResponseCode}
+ */
+proto.message.Response.prototype.getCode = function() {
+  return /** @type {!proto.message.ResponseCode} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {!proto.message.ResponseCode} value
+ * @return {!proto.message.Response} returns this
+ */
+proto.message.Response.prototype.setCode = function(value) {
+  return jspb.Message.setProto3EnumField(this, 1, value);
+};
+
+
+/**
+ * optional string text = 2;
+ * @return {string}
+ */
+proto.message.Response.prototype.getText = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.Response} returns this
+ */
+proto.message.Response.prototype.setText = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.message.Chat.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.Chat.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.message.Chat} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Chat.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    player: (f = msg.getPlayer()) && proto.message.Player.toObject(includeInstance, f),
+    text: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.message.Chat}
+ */
+proto.message.Chat.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.message.Chat;
+  return proto.message.Chat.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.message.Chat} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.message.Chat}
+ */
+proto.message.Chat.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.message.Player;
+      reader.readMessage(value,proto.message.Player.deserializeBinaryFromReader);
+      msg.setPlayer(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setText(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.message.Chat.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.message.Chat.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.message.Chat} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Chat.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPlayer();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      proto.message.Player.serializeBinaryToWriter
+    );
+  }
+  f = message.getText();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional Player player = 1;
+ * @return {?proto.message.Player}
+ */
+proto.message.Chat.prototype.getPlayer = function() {
+  return /** @type{?proto.message.Player} */ (
+    jspb.Message.getWrapperField(this, proto.message.Player, 1));
+};
+
+
+/**
+ * @param {?proto.message.Player|undefined} value
+ * @return {!proto.message.Chat} returns this
+*/
+proto.message.Chat.prototype.setPlayer = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.message.Chat} returns this
+ */
+proto.message.Chat.prototype.clearPlayer = function() {
+  return this.setPlayer(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.message.Chat.prototype.hasPlayer = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional string text = 2;
+ * @return {string}
+ */
+proto.message.Chat.prototype.getText = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.Chat} returns this
+ */
+proto.message.Chat.prototype.setText = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.message.Stabilization.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.Stabilization.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.message.Stabilization} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Stabilization.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    tick: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    period: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    detectedAt: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    population: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    action: jspb.Message.getFieldWithDefault(msg, 5, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.message.Stabilization}
+ */
+proto.message.Stabilization.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.message.Stabilization;
+  return proto.message.Stabilization.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.message.Stabilization} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.message.Stabilization}
+ */
+proto.message.Stabilization.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setTick(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setPeriod(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setDetectedAt(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setPopulation(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setAction(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.message.Stabilization.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.message.Stabilization.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.message.Stabilization} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Stabilization.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getTick();
+  if (f !== 0) {
+    writer.writeUint64(
+      1,
+      f
+    );
+  }
+  f = message.getPeriod();
+  if (f !== 0) {
+    writer.writeUint64(
+      2,
+      f
+    );
+  }
+  f = message.getDetectedAt();
+  if (f !== 0) {
+    writer.writeUint64(
+      3,
+      f
+    );
+  }
+  f = message.getPopulation();
+  if (f !== 0) {
+    writer.writeUint64(
+      4,
+      f
+    );
+  }
+  f = message.getAction();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional uint64 tick = 1;
+ * @return {number}
+ */
+proto.message.Stabilization.prototype.getTick = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Stabilization} returns this
+ */
+proto.message.Stabilization.prototype.setTick = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional uint64 period = 2;
+ * @return {number}
+ */
+proto.message.Stabilization.prototype.getPeriod = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Stabilization} returns this
+ */
+proto.message.Stabilization.prototype.setPeriod = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional uint64 detected_at = 3;
+ * @return {number}
+ */
+proto.message.Stabilization.prototype.getDetectedAt = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Stabilization} returns this
+ */
+proto.message.Stabilization.prototype.setDetectedAt = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional uint64 population = 4;
+ * @return {number}
+ */
+proto.message.Stabilization.prototype.getPopulation = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Stabilization} returns this
+ */
+proto.message.Stabilization.prototype.setPopulation = function(value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional string action = 5;
+ * @return {string}
+ */
+proto.message.Stabilization.prototype.getAction = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.Stabilization} returns this
+ */
+proto.message.Stabilization.prototype.setAction = function(value) {
+  return jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.message.Census.repeatedFields_ = [2];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.message.Census.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.Census.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.message.Census} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Census.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    tick: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    entriesList: jspb.Message.toObjectList(msg.getEntriesList(),
+    proto.message.CensusEntry.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.message.Census}
+ */
+proto.message.Census.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.message.Census;
+  return proto.message.Census.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.message.Census} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.message.Census}
+ */
+proto.message.Census.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setTick(value);
+      break;
+    case 2:
+      var value = new proto.message.CensusEntry;
+      reader.readMessage(value,proto.message.CensusEntry.deserializeBinaryFromReader);
+      msg.addEntries(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.message.Census.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.message.Census.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.message.Census} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Census.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getTick();
+  if (f !== 0) {
+    writer.writeUint64(
+      1,
+      f
+    );
+  }
+  f = message.getEntriesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      2,
+      f,
+      proto.message.CensusEntry.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional uint64 tick = 1;
+ * @return {number}
+ */
+proto.message.Census.prototype.getTick = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.Census} returns this
+ */
+proto.message.Census.prototype.setTick = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * repeated CensusEntry entries = 2;
+ * @return {!Array<!proto.message.CensusEntry>}
+ */
+proto.message.Census.prototype.getEntriesList = function() {
+  return /** @type{!Array<!proto.message.CensusEntry>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.message.CensusEntry, 2));
+};
+
+
+/**
+ * @param {!Array<!proto.message.CensusEntry>} value
+ * @return {!proto.message.Census} returns this
+*/
+proto.message.Census.prototype.setEntriesList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 2, value);
+};
+
+
+/**
+ * @param {!proto.message.CensusEntry=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.message.CensusEntry}
+ */
+proto.message.Census.prototype.addEntries = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.message.CensusEntry, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.message.Census} returns this
+ */
+proto.message.Census.prototype.clearEntriesList = function() {
+  return this.setEntriesList([]);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.message.CensusEntry.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.CensusEntry.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.message.CensusEntry} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.CensusEntry.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    code: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    name: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    type: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    period: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    dy: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    dx: jspb.Message.getFieldWithDefault(msg, 6, 0),
+    count: jspb.Message.getFieldWithDefault(msg, 7, 0),
+    speed: jspb.Message.getFieldWithDefault(msg, 8, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.message.CensusEntry}
+ */
+proto.message.CensusEntry.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.message.CensusEntry;
+  return proto.message.CensusEntry.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.message.CensusEntry} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.message.CensusEntry}
+ */
+proto.message.CensusEntry.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setCode(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setType(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setPeriod(value);
+      break;
+    case 5:
+      var value = /** @type {number} */ (reader.readSint64());
+      msg.setDy(value);
+      break;
+    case 6:
+      var value = /** @type {number} */ (reader.readSint64());
+      msg.setDx(value);
+      break;
+    case 7:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setCount(value);
+      break;
+    case 8:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setSpeed(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.message.CensusEntry.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.message.CensusEntry.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.message.CensusEntry} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.CensusEntry.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getCode();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getType();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getPeriod();
+  if (f !== 0) {
+    writer.writeUint32(
+      4,
+      f
+    );
+  }
+  f = message.getDy();
+  if (f !== 0) {
+    writer.writeSint64(
+      5,
+      f
+    );
+  }
+  f = message.getDx();
+  if (f !== 0) {
+    writer.writeSint64(
+      6,
+      f
+    );
+  }
+  f = message.getCount();
+  if (f !== 0) {
+    writer.writeUint32(
+      7,
+      f
+    );
+  }
+  f = message.getSpeed();
+  if (f.length > 0) {
+    writer.writeString(
+      8,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string code = 1;
+ * @return {string}
+ */
+proto.message.CensusEntry.prototype.getCode = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.CensusEntry} returns this
+ */
+proto.message.CensusEntry.prototype.setCode = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string name = 2;
+ * @return {string}
+ */
+proto.message.CensusEntry.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.CensusEntry} returns this
+ */
+proto.message.CensusEntry.prototype.setName = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string type = 3;
+ * @return {string}
+ */
+proto.message.CensusEntry.prototype.getType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.CensusEntry} returns this
+ */
+proto.message.CensusEntry.prototype.setType = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional uint32 period = 4;
+ * @return {number}
+ */
+proto.message.CensusEntry.prototype.getPeriod = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.CensusEntry} returns this
+ */
+proto.message.CensusEntry.prototype.setPeriod = function(value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional sint64 dy = 5;
+ * @return {number}
+ */
+proto.message.CensusEntry.prototype.getDy = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.CensusEntry} returns this
+ */
+proto.message.CensusEntry.prototype.setDy = function(value) {
+  return jspb.Message.setProto3IntField(this, 5, value);
+};
+
+
+/**
+ * optional sint64 dx = 6;
+ * @return {number}
+ */
+proto.message.CensusEntry.prototype.getDx = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.CensusEntry} returns this
+ */
+proto.message.CensusEntry.prototype.setDx = function(value) {
+  return jspb.Message.setProto3IntField(this, 6, value);
+};
+
+
+/**
+ * optional uint32 count = 7;
+ * @return {number}
+ */
+proto.message.CensusEntry.prototype.getCount = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.CensusEntry} returns this
+ */
+proto.message.CensusEntry.prototype.setCount = function(value) {
+  return jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
+/**
+ * optional string speed = 8;
+ * @return {string}
+ */
+proto.message.CensusEntry.prototype.getSpeed = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.message.CensusEntry} returns this
+ */
+proto.message.CensusEntry.prototype.setSpeed = function(value) {
+  return jspb.Message.setProto3StringField(this, 8, value);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.message.Stats.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.message.Stats.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.Stats.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.message.Stats} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.message.Stats.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    ticksList: jspb.Message.toObjectList(msg.getTicksList(),
+    proto.message.TickStats.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.message.Stats}
+ */
+proto.message.Stats.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.message.Stats;
+  return proto.message.Stats.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.message.Stats} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.message.Stats}
+ */
+proto.message.Stats.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.message.TickStats;
+      reader.readMessage(value,proto.message.TickStats.deserializeBinaryFromReader);
+      msg.addTicks(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.message.Stats.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.message.Stats.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.message.Stats} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.message.Command.prototype.setX = function(value) {
-  return jspb.Message.setProto3IntField(this, 2, value);
+proto.message.Stats.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getTicksList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.message.TickStats.serializeBinaryToWriter
+    );
+  }
 };
 
 
 /**
- * optional uint32 y = 3;
- * @return {number}
+ * repeated TickStats ticks = 1;
+ * @return {!Array<!proto.message.TickStats>}
  */
-proto.message.Command.prototype.getY = function() {
-  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+proto.message.Stats.prototype.getTicksList = function() {
+  return /** @type{!Array<!proto.message.TickStats>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.message.TickStats, 1));
 };
 
 
 /**
- * @param {number} value
- * @return {!proto.message.Command} returns this
- */
-proto.message.Command.prototype.setY = function(value) {
-  return jspb.Message.setProto3IntField(this, 3, value);
+ * @param {!Array<!proto.message.TickStats>} value
+ * @return {!proto.message.Stats} returns this
+*/
+proto.message.Stats.prototype.setTicksList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 1, value);
 };
 
 
 /**
- * optional string text = 4;
- * @return {string}
+ * @param {!proto.message.TickStats=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.message.TickStats}
  */
-proto.message.Command.prototype.getText = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+proto.message.Stats.prototype.addTicks = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.message.TickStats, opt_index);
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.message.Command} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.message.Stats} returns this
  */
-proto.message.Command.prototype.setText = function(value) {
-  return jspb.Message.setProto3StringField(this, 4, value);
+proto.message.Stats.prototype.clearTicksList = function() {
+  return this.setTicksList([]);
 };
 
 
 
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.message.TickStats.repeatedFields_ = [5];
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -1230,8 +3408,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.message.Response.prototype.toObject = function(opt_includeInstance) {
-  return proto.message.Response.toObject(opt_includeInstance, this);
+proto.message.TickStats.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.TickStats.toObject(opt_includeInstance, this);
 };
 
 
@@ -1240,14 +3418,18 @@ proto.message.Response.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.message.Response} msg The msg instance to transform.
+ * @param {!proto.message.TickStats} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.message.Response.toObject = function(includeInstance, msg) {
+proto.message.TickStats.toObject = function(includeInstance, msg) {
   var f, obj = {
-    code: jspb.Message.getFieldWithDefault(msg, 1, 0),
-    text: jspb.Message.getFieldWithDefault(msg, 2, "")
+    tick: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    population: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    births: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    deaths: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    colorsList: jspb.Message.toObjectList(msg.getColorsList(),
+    proto.message.ColorCount.toObject, includeInstance)
   };
 
   if (includeInstance) {
@@ -1261,23 +3443,23 @@ proto.message.Response.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.message.Response}
+ * @return {!proto.message.TickStats}
  */
-proto.message.Response.deserializeBinary = function(bytes) {
+proto.message.TickStats.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.message.Response;
-  return proto.message.Response.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.message.TickStats;
+  return proto.message.TickStats.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.message.Response} msg The message object to deserialize into.
+ * @param {!proto.message.TickStats} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.message.Response}
+ * @return {!proto.message.TickStats}
  */
-proto.message.Response.deserializeBinaryFromReader = function(msg, reader) {
+proto.message.TickStats.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1285,12 +3467,25 @@ proto.message.Response.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {!proto.message.ResponseCode} */ (reader.readEnum());
-      msg.setCode(value);
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setTick(value);
       break;
     case 2:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setText(value);
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setPopulation(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setBirths(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setDeaths(value);
+      break;
+    case 5:
+      var value = new proto.message.ColorCount;
+      reader.readMessage(value,proto.message.ColorCount.deserializeBinaryFromReader);
+      msg.addColors(value);
       break;
     default:
       reader.skipField();
@@ -1305,9 +3500,9 @@ proto.message.Response.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.message.Response.prototype.serializeBinary = function() {
+proto.message.TickStats.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.message.Response.serializeBinaryToWriter(this, writer);
+  proto.message.TickStats.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1315,62 +3510,158 @@ proto.message.Response.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.message.Response} message
+ * @param {!proto.message.TickStats} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.message.Response.serializeBinaryToWriter = function(message, writer) {
+proto.message.TickStats.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getCode();
-  if (f !== 0.0) {
-    writer.writeEnum(
+  f = message.getTick();
+  if (f !== 0) {
+    writer.writeUint64(
       1,
       f
     );
   }
-  f = message.getText();
-  if (f.length > 0) {
-    writer.writeString(
+  f = message.getPopulation();
+  if (f !== 0) {
+    writer.writeUint64(
       2,
       f
     );
   }
+  f = message.getBirths();
+  if (f !== 0) {
+    writer.writeUint64(
+      3,
+      f
+    );
+  }
+  f = message.getDeaths();
+  if (f !== 0) {
+    writer.writeUint64(
+      4,
+      f
+    );
+  }
+  f = message.getColorsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      5,
+      f,
+      proto.message.ColorCount.serializeBinaryToWriter
+    );
+  }
 };
 
 
 /**
- * optional ResponseCode code = 1;
- * @return {!proto.message.ResponseCode}
+ * optional uint64 tick = 1;
+ * @return {number}
  */
-proto.message.Response.prototype.getCode = function() {
-  return /** @type {!proto.message.ResponseCode} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+proto.message.TickStats.prototype.getTick = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
 };
 
 
 /**
- * @param {!proto.message.ResponseCode} value
- * @return {!proto.message.Response} returns this
+ * @param {number} value
+ * @return {!proto.message.TickStats} returns this
  */
-proto.message.Response.prototype.setCode = function(value) {
-  return jspb.Message.setProto3EnumField(this, 1, value);
+proto.message.TickStats.prototype.setTick = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
 };
 
 
 /**
- * optional string text = 2;
- * @return {string}
+ * optional uint64 population = 2;
+ * @return {number}
  */
-proto.message.Response.prototype.getText = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.message.TickStats.prototype.getPopulation = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.message.Response} returns this
+ * @param {number} value
+ * @return {!proto.message.TickStats} returns this
  */
-proto.message.Response.prototype.setText = function(value) {
-  return jspb.Message.setProto3StringField(this, 2, value);
+proto.message.TickStats.prototype.setPopulation = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional uint64 births = 3;
+ * @return {number}
+ */
+proto.message.TickStats.prototype.getBirths = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.TickStats} returns this
+ */
+proto.message.TickStats.prototype.setBirths = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional uint64 deaths = 4;
+ * @return {number}
+ */
+proto.message.TickStats.prototype.getDeaths = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.message.TickStats} returns this
+ */
+proto.message.TickStats.prototype.setDeaths = function(value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * repeated ColorCount colors = 5;
+ * @return {!Array<!proto.message.ColorCount>}
+ */
+proto.message.TickStats.prototype.getColorsList = function() {
+  return /** @type{!Array<!proto.message.ColorCount>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.message.ColorCount, 5));
+};
+
+
+/**
+ * @param {!Array<!proto.message.ColorCount>} value
+ * @return {!proto.message.TickStats} returns this
+*/
+proto.message.TickStats.prototype.setColorsList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 5, value);
+};
+
+
+/**
+ * @param {!proto.message.ColorCount=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.message.ColorCount}
+ */
+proto.message.TickStats.prototype.addColors = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 5, opt_value, proto.message.ColorCount, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.message.TickStats} returns this
+ */
+proto.message.TickStats.prototype.clearColorsList = function() {
+  return this.setColorsList([]);
 };
 
 
@@ -1390,8 +3681,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.message.Chat.prototype.toObject = function(opt_includeInstance) {
-  return proto.message.Chat.toObject(opt_includeInstance, this);
+proto.message.ColorCount.prototype.toObject = function(opt_includeInstance) {
+  return proto.message.ColorCount.toObject(opt_includeInstance, this);
 };
 
 
@@ -1400,14 +3691,14 @@ proto.message.Chat.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.message.Chat} msg The msg instance to transform.
+ * @param {!proto.message.ColorCount} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.message.Chat.toObject = function(includeInstance, msg) {
+proto.message.ColorCount.toObject = function(includeInstance, msg) {
   var f, obj = {
-    player: (f = msg.getPlayer()) && proto.message.Player.toObject(includeInstance, f),
-    text: jspb.Message.getFieldWithDefault(msg, 2, "")
+    color: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    count: jspb.Message.getFieldWithDefault(msg, 2, 0)
   };
 
   if (includeInstance) {
@@ -1421,23 +3712,23 @@ proto.message.Chat.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.message.Chat}
+ * @return {!proto.message.ColorCount}
  */
-proto.message.Chat.deserializeBinary = function(bytes) {
+proto.message.ColorCount.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.message.Chat;
-  return proto.message.Chat.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.message.ColorCount;
+  return proto.message.ColorCount.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.message.Chat} msg The message object to deserialize into.
+ * @param {!proto.message.ColorCount} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.message.Chat}
+ * @return {!proto.message.ColorCount}
  */
-proto.message.Chat.deserializeBinaryFromReader = function(msg, reader) {
+proto.message.ColorCount.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1445,13 +3736,12 @@ proto.message.Chat.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = new proto.message.Player;
-      reader.readMessage(value,proto.message.Player.deserializeBinaryFromReader);
-      msg.setPlayer(value);
+      var value = /** @type {number} */ (reader.readFixed32());
+      msg.setColor(value);
       break;
     case 2:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setText(value);
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setCount(value);
       break;
     default:
       reader.skipField();
@@ -1466,9 +3756,9 @@ proto.message.Chat.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.message.Chat.prototype.serializeBinary = function() {
+proto.message.ColorCount.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.message.Chat.serializeBinaryToWriter(this, writer);
+  proto.message.ColorCount.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1476,23 +3766,22 @@ proto.message.Chat.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.message.Chat} message
+ * @param {!proto.message.ColorCount} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.message.Chat.serializeBinaryToWriter = function(message, writer) {
+proto.message.ColorCount.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getPlayer();
-  if (f != null) {
-    writer.writeMessage(
+  f = message.getColor();
+  if (f !== 0) {
+    writer.writeFixed32(
       1,
-      f,
-      proto.message.Player.serializeBinaryToWriter
+      f
     );
   }
-  f = message.getText();
-  if (f.length > 0) {
-    writer.writeString(
+  f = message.getCount();
+  if (f !== 0) {
+    writer.writeUint64(
       2,
       f
     );
@@ -1501,57 +3790,38 @@ proto.message.Chat.serializeBinaryToWriter = function(message, writer) {
 
 
 /**
- * optional Player player = 1;
- * @return {?proto.message.Player}
- */
-proto.message.Chat.prototype.getPlayer = function() {
-  return /** @type{?proto.message.Player} */ (
-    jspb.Message.getWrapperField(this, proto.message.Player, 1));
-};
-
-
-/**
- * @param {?proto.message.Player|undefined} value
- * @return {!proto.message.Chat} returns this
-*/
-proto.message.Chat.prototype.setPlayer = function(value) {
-  return jspb.Message.setWrapperField(this, 1, value);
-};
-
-
-/**
- * Clears the message field making it undefined.
- * @return {!proto.message.Chat} returns this
+ * optional fixed32 color = 1;
+ * @return {number}
  */
-proto.message.Chat.prototype.clearPlayer = function() {
-  return this.setPlayer(undefined);
+proto.message.ColorCount.prototype.getColor = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {number} value
+ * @return {!proto.message.ColorCount} returns this
  */
-proto.message.Chat.prototype.hasPlayer = function() {
-  return jspb.Message.getField(this, 1) != null;
+proto.message.ColorCount.prototype.setColor = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
 };
 
 
 /**
- * optional string text = 2;
- * @return {string}
+ * optional uint64 count = 2;
+ * @return {number}
  */
-proto.message.Chat.prototype.getText = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.message.ColorCount.prototype.getCount = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
 };
 
 
 /**
- * @param {string} value
- * @return {!proto.message.Chat} returns this
+ * @param {number} value
+ * @return {!proto.message.ColorCount} returns this
  */
-proto.message.Chat.prototype.setText = function(value) {
-  return jspb.Message.setProto3StringField(this, 2, value);
+proto.message.ColorCount.prototype.setCount = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
 };
 
 
@@ -1969,7 +4239,10 @@ proto.message.MessageType = {
   COMMAND: 3,
   RESPONSE: 4,
   CHAT_LOG: 5,
-  RLE_OPTIONS: 6
+  RLE_OPTIONS: 6,
+  STABILIZED: 7,
+  CENSUS: 8,
+  STATS: 9
 };
 
 /**
@@ -1980,7 +4253,20 @@ proto.message.CommandType = {
   PLACE_RLE: 1,
   TOGGLE_PAUSE: 2,
   POST_CHAT: 3,
-  CLEAR_BOARD: 4
+  CLEAR_BOARD: 4,
+  STEP_BACK: 5,
+  STEP_FORWARD: 6,
+  JUMP_TO_TICK: 7,
+  RANDOMIZE_REGION: 8,
+  PAINT_STATE: 9,
+  TOGGLE_REVERSE: 10,
+  RESIZE: 11,
+  COPY_REGION: 12,
+  CUT_REGION: 13,
+  PASTE: 14,
+  MOVE_REGION: 15,
+  CLEAR_REGION: 16,
+  FORK: 17
 };
 
 /**